@@ -6,6 +6,7 @@ package json
 import (
 	stdjson "encoding/json"
 	"errors"
+	"io"
 
 	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
 	"github.com/go-openapi/swag/typeutils"
@@ -20,6 +21,9 @@ var ErrMaxNestingDepth = errors.New("maximum JSON nesting depth exceeded")
 
 var _ ifaces.Adapter = &Adapter{}
 
+// flushThreshold is the size of the buffered output above which [Adapter.Encode] writes it to its output stream.
+const flushThreshold = 8192
+
 type Adapter struct {
 	options
 }
@@ -75,7 +79,7 @@ func (a *Adapter) OrderedMarshal(value ifaces.Ordered) ([]byte, error) {
 	w, redeem := BorrowWriter()
 	defer redeem()
 
-	a.orderedMarshal(w, value, a.maxDepth())
+	a.orderedMarshal(w, value, a.maxDepth(), nil)
 
 	return w.BuildBytes() // this actually copies data, so its okay to redeem the writer
 }
@@ -117,7 +121,9 @@ func (a *Adapter) Reset() {
 
 // orderedMarshal writes value to w, decreasing budget for every nested container to
 // guard against stack overflow on deeply nested structures.
-func (a *Adapter) orderedMarshal(w *jwriter.Writer, value ifaces.Ordered, budget int) {
+//
+// When out is not nil, the output buffered in w is flushed to out as members are written.
+func (a *Adapter) orderedMarshal(w *jwriter.Writer, value ifaces.Ordered, budget int, out io.Writer) {
 	if typeutils.IsNil(value) {
 		w.RawString("null")
 
@@ -146,13 +152,26 @@ func (a *Adapter) orderedMarshal(w *jwriter.Writer, value ifaces.Ordered, budget
 		case ifaces.Ordered:
 			// ordered values (including this package's MapSlice) recurse through the
 			// depth-guarded path rather than their own unbounded MarshalEasyJSON.
-			a.orderedMarshal(w, val, budget-1)
+			a.orderedMarshal(w, val, budget-1, out)
 		case easyjson.Marshaler:
 			val.MarshalEasyJSON(w)
 		default:
 			w.Raw(stdjson.Marshal(v))
 		}
+
+		flush(w, out)
 	}
 
 	w.RawByte('}')
 }
+
+// flush writes the output buffered in w to out, if any, once it holds at least flushThreshold bytes.
+func flush(w *jwriter.Writer, out io.Writer) {
+	if out == nil || w.Error != nil || w.Size() < flushThreshold {
+		return
+	}
+
+	if _, err := w.DumpTo(out); err != nil {
+		w.Error = err
+	}
+}
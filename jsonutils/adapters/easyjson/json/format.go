@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"strings"
+
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+	"github.com/go-openapi/swag/typeutils"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+var _ ifaces.FormatMarshalAdapter = &Adapter{}
+
+// FormatMarshal marshals a value as JSON, with the layout described by [ifaces.FormatOptions].
+//
+// Values that implement [ifaces.Ordered] are indented in a single pass, with the order of keys maintained.
+//
+// Since the [jwriter.Writer] knows no indentation, the output of [easyjson.Marshaler] values
+// is indented like [stdjson.Indent] does.
+func (a *Adapter) FormatMarshal(value any, format ifaces.FormatOptions) ([]byte, error) {
+	w, redeem := BorrowWriter()
+	defer redeem()
+
+	if a.nilMapAsEmpty {
+		w.Flags |= jwriter.NilMapAsEmpty
+	}
+	if a.nilSliceAsEmpty {
+		w.Flags |= jwriter.NilSliceAsEmpty
+	}
+	w.NoEscapeHTML = !format.EscapeHTML
+
+	if ordered, isOrdered := value.(ifaces.Ordered); isOrdered {
+		a.formatOrdered(w, ordered, format, a.maxDepth(), 0)
+	} else {
+		a.formatValue(w, value, format, 0)
+	}
+
+	if format.TrailingNewline {
+		w.RawByte('\n')
+	}
+
+	return w.BuildBytes() // this actually copies data, so its okay to redeem the writer
+}
+
+// formatOrdered is like orderedMarshal, with the layout described by format.
+func (a *Adapter) formatOrdered(w *jwriter.Writer, value ifaces.Ordered, format ifaces.FormatOptions, budget, level int) {
+	if typeutils.IsNil(value) {
+		w.RawString("null")
+
+		return
+	}
+
+	if budget <= 0 {
+		w.Error = ErrMaxNestingDepth
+
+		return
+	}
+
+	w.RawByte('{')
+	first := true
+	for k, v := range value.OrderedItems() {
+		if first {
+			first = false
+		} else {
+			w.RawByte(',')
+		}
+
+		formatNewline(w, format, level+1)
+		w.String(k)
+		w.RawByte(':')
+		if format.IsIndented() {
+			w.RawByte(' ')
+		}
+
+		a.formatNested(w, v, format, budget, level+1)
+	}
+
+	if !first {
+		formatNewline(w, format, level)
+	}
+
+	w.RawByte('}')
+}
+
+// formatArray lays out arrays, so ordered maps nested in arrays are formatted in the same pass.
+func (a *Adapter) formatArray(w *jwriter.Writer, value []any, format ifaces.FormatOptions, budget, level int) {
+	if value == nil {
+		w.RawString("null")
+
+		return
+	}
+
+	if budget <= 0 {
+		w.Error = ErrMaxNestingDepth
+
+		return
+	}
+
+	w.RawByte('[')
+	for i, v := range value {
+		if i > 0 {
+			w.RawByte(',')
+		}
+
+		formatNewline(w, format, level+1)
+		a.formatNested(w, v, format, budget, level+1)
+	}
+
+	if len(value) > 0 {
+		formatNewline(w, format, level)
+	}
+
+	w.RawByte(']')
+}
+
+// formatNested formats a value nested in a container at some indentation level.
+func (a *Adapter) formatNested(w *jwriter.Writer, value any, format ifaces.FormatOptions, budget, level int) {
+	switch val := value.(type) {
+	case ifaces.Ordered:
+		a.formatOrdered(w, val, format, budget-1, level)
+	case []any:
+		a.formatArray(w, val, format, budget-1, level)
+	default:
+		a.formatValue(w, value, format, level)
+	}
+}
+
+// formatValue formats any other value, at some indentation level.
+func (a *Adapter) formatValue(w *jwriter.Writer, value any, format ifaces.FormatOptions, level int) {
+	prefix := format.Prefix + strings.Repeat(format.Indent, level)
+
+	marshaler, isMarshaler := value.(easyjson.Marshaler)
+	if !isMarshaler {
+		// fallback to standard library
+		var buf bytes.Buffer
+		enc := stdjson.NewEncoder(&buf)
+		enc.SetEscapeHTML(format.EscapeHTML)
+		enc.SetIndent(prefix, format.Indent)
+
+		err := enc.Encode(value)
+		w.Raw(bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), err)
+
+		return
+	}
+
+	if !format.IsIndented() {
+		marshaler.MarshalEasyJSON(w)
+
+		return
+	}
+
+	nested := jwriter.Writer{Flags: w.Flags, NoEscapeHTML: w.NoEscapeHTML}
+	marshaler.MarshalEasyJSON(&nested)
+	data, err := nested.BuildBytes()
+	if err != nil {
+		w.Raw(nil, err)
+
+		return
+	}
+
+	var buf bytes.Buffer
+	err = stdjson.Indent(&buf, data, prefix, format.Indent)
+	w.Raw(buf.Bytes(), err)
+}
+
+// formatNewline starts a new line of indented JSON, at some indentation level.
+func formatNewline(w *jwriter.Writer, format ifaces.FormatOptions, level int) {
+	if !format.IsIndented() {
+		return
+	}
+
+	w.RawByte('\n')
+	w.RawString(format.Prefix)
+	w.RawString(strings.Repeat(format.Indent, level))
+}
@@ -4,8 +4,11 @@
 package json
 
 import (
+	stdjson "encoding/json"
 	"testing"
 
+	"github.com/go-openapi/swag/jsonutils"
+	"github.com/go-openapi/swag/jsonutils/adapters"
 	fixtures "github.com/go-openapi/swag/jsonutils/fixtures_test"
 	"github.com/go-openapi/testify/v2/require"
 )
@@ -45,3 +48,25 @@ func TestSetOrdered(t *testing.T) {
 		require.Nil(t, m)
 	})
 }
+
+func TestCanonicalJSON(t *testing.T) {
+	adapters.Registry.Reset()
+	Register(adapters.Registry)
+	t.Cleanup(adapters.Registry.Reset)
+
+	const jazon = `{"z":{"y":[1,2.50,"<&>"],"x":null},"a":true}`
+
+	m := MapSlice{}
+	require.NoError(t, m.UnmarshalJSON([]byte(jazon)))
+
+	var unordered any
+	require.NoError(t, stdjson.Unmarshal([]byte(jazon), &unordered))
+
+	expected, err := jsonutils.CanonicalJSON(unordered)
+	require.NoError(t, err)
+	require.EqualT(t, `{"a":true,"z":{"x":null,"y":[1,2.5,"<&>"]}}`, string(expected))
+
+	canonical, err := jsonutils.CanonicalJSON(m)
+	require.NoError(t, err)
+	require.EqualT(t, string(expected), string(canonical))
+}
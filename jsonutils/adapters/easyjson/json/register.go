@@ -25,7 +25,7 @@ func Register(dispatcher ifaces.Registrar, opts ...Option) {
 	dispatcher.RegisterFor(
 		ifaces.RegistryEntry{
 			Who:  fmt.Sprintf("%s.%s", t.PkgPath(), t.Name()),
-			What: ifaces.AllCapabilities,
+			What: ifaces.AllCapabilities | ifaces.AllStreamingCapabilities | ifaces.Capabilities(ifaces.CapabilityFormatJSON),
 			Constructor: func() ifaces.Adapter {
 				a := BorrowAdapter()
 				a.options = o
@@ -38,14 +38,18 @@ func Register(dispatcher ifaces.Registrar, opts ...Option) {
 
 func support(capability ifaces.Capability, value any) bool {
 	switch capability {
-	case ifaces.CapabilityMarshalJSON, ifaces.CapabilityOrderedMarshalJSON:
+	case ifaces.CapabilityMarshalJSON, ifaces.CapabilityOrderedMarshalJSON, ifaces.CapabilityEncodeJSON, ifaces.CapabilityFormatJSON:
 		_, ok := value.(easyjson.Marshaler)
 		return ok
-	case ifaces.CapabilityUnmarshalJSON, ifaces.CapabilityOrderedUnmarshalJSON:
+	case ifaces.CapabilityUnmarshalJSON, ifaces.CapabilityOrderedUnmarshalJSON, ifaces.CapabilityDecodeJSON:
 		_, ok := value.(easyjson.Unmarshaler)
 		return ok
 	case ifaces.CapabilityOrderedMap:
 		return true
+	case ifaces.CapabilityTokenizeJSON:
+		// the easyjson lexer works on bytes: only in-memory readers are supported
+		_, ok := value.(interface{ Len() int })
+		return ok
 	default:
 		return false
 	}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	stdjson "encoding/json"
+	"io"
+
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+var (
+	_ ifaces.EncodeAdapter = &Adapter{}
+	_ ifaces.DecodeAdapter = &Adapter{}
+)
+
+// Encode writes the JSON encoding of value to out.
+//
+// Values that implement [ifaces.Ordered] are written like [Adapter.OrderedMarshal] does:
+// the output is written to out in chunks, as the members of ordered maps are encoded.
+//
+// Other values are written like [Adapter.Marshal] does: their whole output is built in memory,
+// then written to out without being copied first.
+//
+// When an error is returned, part of the output may already have been written to out.
+func (a *Adapter) Encode(out io.Writer, value any) error {
+	ordered, isOrdered := value.(ifaces.Ordered)
+	marshaler, isMarshaler := value.(easyjson.Marshaler)
+	if !isOrdered && !isMarshaler {
+		// fallback to standard library
+		data, err := stdjson.Marshal(value)
+		if err != nil {
+			return err
+		}
+
+		_, err = out.Write(data)
+
+		return err
+	}
+
+	w, redeem := BorrowWriter()
+	defer redeem()
+
+	if isOrdered {
+		a.orderedMarshal(w, ordered, a.maxDepth(), out)
+	} else {
+		if a.nilMapAsEmpty {
+			w.Flags |= jwriter.NilMapAsEmpty
+		}
+		if a.nilSliceAsEmpty {
+			w.Flags |= jwriter.NilSliceAsEmpty
+		}
+		w.NoEscapeHTML = a.noEscapeHTML
+
+		marshaler.MarshalEasyJSON(w)
+	}
+
+	if w.Error != nil {
+		return w.Error
+	}
+
+	_, err := w.DumpTo(out)
+
+	return err
+}
+
+// Decode reads the next JSON value from r and stores it in value.
+//
+// Values that implement [ifaces.SetOrdered] are read like [Adapter.OrderedUnmarshal] does.
+// Other values are read like [Adapter.Unmarshal] does.
+//
+// The [jlexer.Lexer] works on bytes: the JSON value is read from r and buffered before it is decoded,
+// but r is not read until [io.EOF].
+//
+// Like with [stdjson.Decoder], Decode may read data from r beyond the JSON value.
+func (a *Adapter) Decode(r io.Reader, value any) error {
+	var data stdjson.RawMessage
+	if err := stdjson.NewDecoder(r).Decode(&data); err != nil {
+		return err
+	}
+
+	if ordered, isOrdered := value.(ifaces.SetOrdered); isOrdered {
+		return a.OrderedUnmarshal(data, ordered)
+	}
+
+	return a.Unmarshal(data, value)
+}
@@ -0,0 +1,255 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/go-openapi/swag/jsonutils"
+	"github.com/go-openapi/swag/jsonutils/adapters"
+	fixtures "github.com/go-openapi/swag/jsonutils/fixtures_test"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+	"github.com/mailru/easyjson/jlexer"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+func TestAdapterStream(t *testing.T) {
+	// not parallel: the adapter is registered globally to be reached through [jsonutils.WriteJSONTo]
+	record := easyRecord{Text: "<&>"}
+
+	for _, test := range []struct {
+		name     string
+		opts     []Option
+		value    easyRecord
+		expected string
+	}{
+		{
+			name:     "with default options",
+			value:    record,
+			expected: `{"map":null,"slice":null,"text":"\u003c\u0026\u003e"}`,
+		},
+		{
+			name:     "with NilMapAsEmpty",
+			opts:     []Option{WithWriterNilMapAsEmpty(true)},
+			value:    record,
+			expected: `{"map":{},"slice":null,"text":"\u003c\u0026\u003e"}`,
+		},
+		{
+			name:     "with NilSliceAsEmpty",
+			opts:     []Option{WithWriterNilSliceAsEmpty(true)},
+			value:    record,
+			expected: `{"map":null,"slice":[],"text":"\u003c\u0026\u003e"}`,
+		},
+		{
+			name:     "with NoEscapeHTML",
+			opts:     []Option{WithWriterNoEscapeHTML(true)},
+			value:    record,
+			expected: `{"map":null,"slice":null,"text":"<&>"}`,
+		},
+		{
+			name:     "with non-nil map and slice",
+			opts:     []Option{WithWriterNilMapAsEmpty(true), WithWriterNilSliceAsEmpty(true)},
+			value:    easyRecord{Map: map[string]string{"b": "2", "a": "1"}, Slice: []string{"x"}, Text: "t"},
+			expected: `{"map":{"a":"1","b":"2"},"slice":["x"],"text":"t"}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			adapters.Registry.Reset()
+			Register(adapters.Registry, test.opts...)
+			t.Cleanup(adapters.Registry.Reset)
+
+			t.Run("should Encode an easyjson.Marshaler like Marshal", func(t *testing.T) {
+				expected, err := NewAdapter(test.opts...).Marshal(test.value)
+				require.NoError(t, err)
+				require.EqualT(t, test.expected, string(expected))
+
+				var buf bytes.Buffer
+				require.NoError(t, jsonutils.WriteJSONTo(&buf, test.value))
+				assert.EqualT(t, string(expected), buf.String())
+			})
+
+			t.Run("should Decode an easyjson.Unmarshaler", func(t *testing.T) {
+				var decoded easyRecord
+				require.NoError(t, jsonutils.ReadJSONFrom(strings.NewReader(test.expected), &decoded))
+				assert.TrueT(t, decoded.decoded)
+				assert.EqualT(t, test.value.Text, decoded.Text)
+
+				// a null map or slice is decoded as nil, and an empty one as empty
+				data, err := NewAdapter(test.opts...).Marshal(decoded)
+				require.NoError(t, err)
+				assert.EqualT(t, test.expected, string(data))
+			})
+		})
+	}
+
+	a := BorrowAdapter()
+	defer func() {
+		RedeemAdapter(a)
+	}()
+
+	t.Run("should Encode other values with the standard library", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, a.Encode(&buf, map[string]any{"a": []int{1, 2}, "b": "<html>"}))
+		assert.EqualT(t, `{"a":[1,2],"b":"\u003chtml\u003e"}`, buf.String())
+
+		require.Error(t, a.Encode(&buf, func() {}))
+		require.ErrorIs(t, a.Encode(failingWriter{}, []int{1}), errWrite)
+	})
+
+	t.Run("should report errors when decoding an easyjson.Unmarshaler", func(t *testing.T) {
+		var decoded easyRecord
+		require.Error(t, a.Decode(strings.NewReader(`{"text":1}`), &decoded))
+		require.Error(t, a.Decode(strings.NewReader(`{"text":`), &decoded))
+	})
+
+	t.Run("should Encode large ordered maps in chunks", func(t *testing.T) {
+		const members = 1000
+		value := make(MapSlice, 0, members)
+		for i := range members {
+			value = append(value, MapItem{Key: fmt.Sprintf("key%04d", i), Value: easyValue{"v", strings.Repeat("x", 32)}})
+		}
+
+		expected, err := a.OrderedMarshal(value)
+		require.NoError(t, err)
+
+		var out chunksWriter
+		require.NoError(t, a.Encode(&out, value))
+		assert.EqualT(t, string(expected), out.String())
+		assert.GreaterT(t, len(out.chunks), 1)
+		for _, chunk := range out.chunks[:len(out.chunks)-1] {
+			assert.LessT(t, chunk, 2*flushThreshold)
+		}
+
+		require.ErrorIs(t, a.Encode(failingWriter{}, value), errWrite)
+	})
+
+	t.Run("should Decode ordered maps without reading until EOF", func(t *testing.T) {
+		r := io.MultiReader(strings.NewReader(`{"z":1,"a":2}`), iotest.ErrReader(errRead))
+
+		var value MapSlice
+		require.NoError(t, a.Decode(r, &value))
+
+		var buf bytes.Buffer
+		require.NoError(t, a.Encode(&buf, value))
+		fixtures.JSONEqualOrderedBytes(t, []byte(`{"z":1,"a":2}`), buf.Bytes())
+	})
+}
+
+// easyRecord is an [easyjson.Marshaler] and an [easyjson.Unmarshaler], written like the code generated by easyjson.
+type easyRecord struct {
+	Map   map[string]string
+	Slice []string
+	Text  string
+
+	decoded bool
+}
+
+func (r easyRecord) MarshalEasyJSON(w *jwriter.Writer) {
+	w.RawString(`{"map":`)
+	if r.Map == nil && w.Flags&jwriter.NilMapAsEmpty == 0 {
+		w.RawString("null")
+	} else {
+		w.RawByte('{')
+		for i, key := range slices.Sorted(maps.Keys(r.Map)) {
+			if i > 0 {
+				w.RawByte(',')
+			}
+			w.String(key)
+			w.RawByte(':')
+			w.String(r.Map[key])
+		}
+		w.RawByte('}')
+	}
+
+	w.RawString(`,"slice":`)
+	if r.Slice == nil && w.Flags&jwriter.NilSliceAsEmpty == 0 {
+		w.RawString("null")
+	} else {
+		w.RawByte('[')
+		for i, item := range r.Slice {
+			if i > 0 {
+				w.RawByte(',')
+			}
+			w.String(item)
+		}
+		w.RawByte(']')
+	}
+
+	w.RawString(`,"text":`)
+	w.String(r.Text)
+	w.RawByte('}')
+}
+
+func (r *easyRecord) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	r.decoded = true
+
+	l.Delim('{')
+	for !l.IsDelim('}') {
+		key := l.UnsafeFieldName(false)
+		l.WantColon()
+
+		switch {
+		case l.IsNull():
+			l.Skip()
+		case key == "map":
+			r.Map = make(map[string]string)
+			l.Delim('{')
+			for !l.IsDelim('}') {
+				k := l.UnsafeFieldName(false)
+				l.WantColon()
+				r.Map[k] = l.String()
+				l.WantComma()
+			}
+			l.Delim('}')
+		case key == "slice":
+			r.Slice = []string{}
+			l.Delim('[')
+			for !l.IsDelim(']') {
+				r.Slice = append(r.Slice, l.String())
+				l.WantComma()
+			}
+			l.Delim(']')
+		case key == "text":
+			r.Text = l.String()
+		default:
+			l.SkipRecursive()
+		}
+
+		l.WantComma()
+	}
+	l.Delim('}')
+}
+
+var (
+	errRead  = errors.New("test read error")
+	errWrite = errors.New("test write error")
+)
+
+// chunksWriter records the size of every write.
+type chunksWriter struct {
+	bytes.Buffer
+
+	chunks []int
+}
+
+func (w *chunksWriter) Write(data []byte) (int, error) {
+	w.chunks = append(w.chunks, len(data))
+
+	return w.Buffer.Write(data)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errWrite
+}
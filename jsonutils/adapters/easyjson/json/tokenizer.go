@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+	"github.com/mailru/easyjson/jlexer"
+)
+
+var (
+	_ ifaces.TokenizerAdapter = &Adapter{}
+	_ ifaces.Tokenizer        = &tokenizer{}
+)
+
+// NewTokenizer yields an [ifaces.Tokenizer] that reads JSON tokens from r.
+//
+// The [jlexer.Lexer] works on bytes: the reader is consumed entirely before the first token is returned.
+// This is why this adapter only registers the [ifaces.CapabilityTokenizeJSON] capability for in-memory readers,
+// such as [bytes.Reader] or [strings.Reader].
+//
+// The nesting depth of containers is limited, like with [Adapter.OrderedUnmarshal]. See [WithMaxNestingDepth].
+func (a *Adapter) NewTokenizer(r io.Reader) ifaces.Tokenizer {
+	t := &tokenizer{
+		maxDepth: a.maxDepth(),
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.err = err
+
+		return t
+	}
+
+	t.lexer, t.redeem = BorrowLexer(data)
+	t.lexer.UseMultipleErrors = false
+
+	return t
+}
+
+// tokenizer drives an easyjson lexer as a state machine, since [jlexer.Lexer] expects
+// the caller to know the structure of the document.
+type tokenizer struct {
+	lexer      *jlexer.Lexer
+	redeem     func()
+	err        error
+	containers []byte // stack of open containers, '{' or '['
+	expectKey  bool
+	maxDepth   int
+}
+
+func (t *tokenizer) NextToken() (ifaces.Token, error) {
+	if t.err != nil {
+		return ifaces.Token{}, t.err
+	}
+
+	l := t.lexer
+	if l == nil {
+		return ifaces.Token{}, errors.New("tokenizer used after it has been redeemed")
+	}
+
+	token := t.next(l)
+	if err := l.Error(); err != nil {
+		if errors.Is(err, io.EOF) && len(t.containers) > 0 {
+			err = fmt.Errorf("unterminated JSON container: %w", io.ErrUnexpectedEOF)
+		}
+		t.err = err
+
+		return ifaces.Token{}, err
+	}
+
+	return token, nil
+}
+
+// Redeem the lexer to its pool.
+func (t *tokenizer) Redeem() {
+	if t == nil || t.redeem == nil {
+		return
+	}
+
+	t.redeem()
+	t.redeem = nil
+	t.lexer = nil
+	t.containers = nil
+}
+
+func (t *tokenizer) next(l *jlexer.Lexer) ifaces.Token {
+	switch l.CurrentToken() {
+	case jlexer.TokenDelim:
+		return t.delim(l)
+	case jlexer.TokenString:
+		value := l.String()
+		if t.expectKey {
+			t.expectKey = false
+			l.WantColon()
+
+			return ifaces.Token{Kind: ifaces.TokenKey, Text: value}
+		}
+		t.endValue(l)
+
+		return ifaces.Token{Kind: ifaces.TokenString, Text: value}
+	case jlexer.TokenNumber, jlexer.TokenBool, jlexer.TokenNull:
+		if t.expectKey {
+			l.AddError(&jlexer.LexerError{Reason: "expected an object key", Offset: l.GetPos()})
+
+			return ifaces.Token{}
+		}
+
+		return t.scalar(l)
+	default: // an error or the end of the input
+		return ifaces.Token{}
+	}
+}
+
+func (t *tokenizer) scalar(l *jlexer.Lexer) ifaces.Token {
+	var token ifaces.Token
+
+	switch l.CurrentToken() { //nolint:exhaustive // only scalars other than strings are expected here
+	case jlexer.TokenNumber:
+		token = ifaces.Token{Kind: ifaces.TokenNumber, Text: string(l.JsonNumber())}
+	case jlexer.TokenBool:
+		token = ifaces.Token{Kind: ifaces.TokenBool, Text: strconv.FormatBool(l.Bool())}
+	default:
+		l.Null()
+		token = ifaces.Token{Kind: ifaces.TokenNull, Text: "null"}
+	}
+	t.endValue(l)
+
+	return token
+}
+
+func (t *tokenizer) delim(l *jlexer.Lexer) ifaces.Token {
+	for _, c := range []byte("{[") {
+		if !l.IsDelim(c) {
+			continue
+		}
+
+		if t.expectKey {
+			l.AddError(&jlexer.LexerError{Reason: "expected an object key", Offset: l.GetPos()})
+
+			return ifaces.Token{}
+		}
+
+		if len(t.containers) >= t.maxDepth {
+			l.AddError(ErrMaxNestingDepth)
+
+			return ifaces.Token{}
+		}
+
+		l.Delim(c)
+		t.containers = append(t.containers, c)
+		t.expectKey = c == '{'
+
+		if c == '{' {
+			return ifaces.Token{Kind: ifaces.TokenBeginObject, Text: "{"}
+		}
+
+		return ifaces.Token{Kind: ifaces.TokenBeginArray, Text: "["}
+	}
+
+	// closing delimiter: the lexer checks separators, but not that delimiters are balanced
+	var want byte = ']'
+	if len(t.containers) > 0 && t.containers[len(t.containers)-1] == '{' {
+		want = '}'
+	}
+
+	if len(t.containers) == 0 || !l.IsDelim(want) {
+		l.AddError(&jlexer.LexerError{Reason: "unbalanced delimiter", Offset: l.GetPos()})
+
+		return ifaces.Token{}
+	}
+
+	l.Delim(want)
+	t.containers = t.containers[:len(t.containers)-1]
+	t.endValue(l)
+
+	if want == '}' {
+		return ifaces.Token{Kind: ifaces.TokenEndObject, Text: "}"}
+	}
+
+	return ifaces.Token{Kind: ifaces.TokenEndArray, Text: "]"}
+}
+
+// endValue expects a separator whenever a value completes inside a container.
+func (t *tokenizer) endValue(l *jlexer.Lexer) {
+	if len(t.containers) == 0 {
+		t.expectKey = false
+
+		return
+	}
+
+	t.expectKey = t.containers[len(t.containers)-1] == '{'
+	l.WantComma()
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+	"github.com/mailru/easyjson/jlexer"
+)
+
+// tokens reads all tokens from a JSON stream, until the end of the stream or an error.
+func tokens(t *testing.T, a *Adapter, input string) ([]string, error) {
+	t.Helper()
+
+	tokenizer := a.NewTokenizer(strings.NewReader(input))
+	defer tokenizer.Redeem()
+
+	var result []string
+	for {
+		token, err := tokenizer.NextToken()
+		if errors.Is(err, io.EOF) {
+			return result, nil
+		}
+		if err != nil {
+			return result, err
+		}
+
+		result = append(result, token.String())
+	}
+}
+
+func TestTokenizer(t *testing.T) {
+	a := NewAdapter()
+
+	t.Run("should tell keys from string values", func(t *testing.T) {
+		result, err := tokens(t, a, `{"a":"b","c":["d",{"e":"f"}],"g":{}}`)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"BeginObject({)", "Key(a)", "String(b)",
+			"Key(c)", "BeginArray([)", "String(d)", "BeginObject({)", "Key(e)", "String(f)", "EndObject(})", "EndArray(])",
+			"Key(g)", "BeginObject({)", "EndObject(})",
+			"EndObject(})",
+		}, result)
+	})
+
+	t.Run("should report scalars with their literal", func(t *testing.T) {
+		result, err := tokens(t, a, `[1.50, -2e3, true, false, null, "é"]`)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"BeginArray([)", "Number(1.50)", "Number(-2e3)", "Bool(true)", "Bool(false)", "Null(null)", "String(é)", "EndArray(])",
+		}, result)
+	})
+
+	t.Run("should read a stream of values", func(t *testing.T) {
+		result, err := tokens(t, a, `{"a":1} [2] "x"`)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"BeginObject({)", "Key(a)", "Number(1)", "EndObject(})", "BeginArray([)", "Number(2)", "EndArray(])", "String(x)",
+		}, result)
+	})
+
+	t.Run("should error on a truncated document", func(t *testing.T) {
+		_, err := tokens(t, a, `{"a":[1`)
+		require.Error(t, err)
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	})
+
+	for _, input := range []string{
+		`{"a" 1}`,
+		`{"a":1,}`,
+		`{1:2}`,
+		`{[]:2}`,
+		`[1}`,
+		`]`,
+		`[1 2]`,
+	} {
+		t.Run("should error on an invalid document: "+input, func(t *testing.T) {
+			_, err := tokens(t, a, input)
+			require.Error(t, err)
+
+			var lexerErr *jlexer.LexerError
+			require.ErrorAs(t, err, &lexerErr)
+		})
+	}
+
+	t.Run("should enforce the max nesting depth", func(t *testing.T) {
+		shallow := NewAdapter(WithMaxNestingDepth(5))
+
+		_, err := tokens(t, shallow, `{"a":{"a":{"a":{"a":{}}}}}`)
+		require.NoError(t, err)
+
+		_, err = tokens(t, shallow, `{"a":[[[[[]]]]]}`)
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrMaxNestingDepth)
+	})
+
+	t.Run("should not be usable after redeem", func(t *testing.T) {
+		tokenizer := a.NewTokenizer(strings.NewReader(`{}`))
+		tokenizer.Redeem()
+		tokenizer.Redeem()
+
+		_, err := tokenizer.NextToken()
+		require.Error(t, err)
+	})
+
+	t.Run("should register the tokenizer capability for in-memory readers only", func(t *testing.T) {
+		assert.TrueT(t, support(ifaces.CapabilityTokenizeJSON, strings.NewReader("")))
+		assert.FalseT(t, support(ifaces.CapabilityTokenizeJSON, bufio.NewReader(strings.NewReader(""))))
+	})
+}
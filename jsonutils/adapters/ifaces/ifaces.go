@@ -5,6 +5,7 @@ package ifaces
 
 import (
 	_ "encoding/json" // for documentation purpose
+	"io"
 	"iter"
 )
 
@@ -59,6 +60,65 @@ type OrderedUnmarshalAdapter interface {
 	OrderedUnmarshal([]byte, SetOrdered) error
 }
 
+// EncodeAdapter behaves likes the standard library [json.Encoder], writing JSON to a stream.
+//
+// Values that implement [Ordered] are written with the order of keys maintained.
+//
+// Unlike [json.Encoder], no newline is written after the JSON value.
+//
+// It is an optional interface that an [Adapter] registered with the [CapabilityEncodeJSON] capability implements.
+type EncodeAdapter interface {
+	Poolable
+
+	Encode(io.Writer, any) error
+}
+
+// DecodeAdapter behaves likes the standard library [json.Decoder], reading one JSON value from a stream.
+//
+// Values that implement [SetOrdered] are read with the order of keys maintained.
+//
+// It is an optional interface that an [Adapter] registered with the [CapabilityDecodeJSON] capability implements.
+type DecodeAdapter interface {
+	Poolable
+
+	Decode(io.Reader, any) error
+}
+
+// FormatOptions describe the layout of the JSON written by a [FormatMarshalAdapter].
+//
+// The zero value writes compact JSON, without escaping HTML characters and without a trailing newline.
+type FormatOptions struct {
+	// Prefix starts every line of indented JSON, except the first one, like with [json.MarshalIndent].
+	Prefix string
+
+	// Indent is repeated for every nesting level of indented JSON, like with [json.MarshalIndent].
+	//
+	// JSON is indented whenever Prefix or Indent is not empty.
+	Indent string
+
+	// EscapeHTML escapes the characters <, > and & in JSON strings, like [json.Marshal] does.
+	EscapeHTML bool
+
+	// TrailingNewline terminates the JSON with a newline, like [json.Encoder] does.
+	TrailingNewline bool
+}
+
+// IsIndented tells if these options produce indented JSON.
+func (o FormatOptions) IsIndented() bool {
+	return o.Prefix != "" || o.Indent != ""
+}
+
+// FormatMarshalAdapter marshals JSON with some control over the layout of the output.
+//
+// Values that implement [Ordered] are written with the order of keys maintained.
+//
+// It is an optional interface that an [Adapter] registered with the [CapabilityFormatJSON] capability implements.
+type FormatMarshalAdapter interface {
+	Poolable
+
+	FormatMarshal(any, FormatOptions) ([]byte, error)
+}
+
 // Adapter exposes an interface like the standard [json] library.
 type Adapter interface {
 	MarshalAdapter
@@ -8,7 +8,7 @@ import (
 )
 
 // Capability indicates what a JSON adapter is capable of.
-type Capability uint8
+type Capability uint16
 
 const (
 	CapabilityMarshalJSON Capability = 1 << iota
@@ -16,6 +16,10 @@ const (
 	CapabilityOrderedMarshalJSON
 	CapabilityOrderedUnmarshalJSON
 	CapabilityOrderedMap
+	CapabilityTokenizeJSON
+	CapabilityEncodeJSON
+	CapabilityDecodeJSON
+	CapabilityFormatJSON
 )
 
 func (c Capability) String() string {
@@ -30,13 +34,21 @@ func (c Capability) String() string {
 		return "OrderedUnmarshalJSON"
 	case CapabilityOrderedMap:
 		return "OrderedMap"
+	case CapabilityTokenizeJSON:
+		return "TokenizeJSON"
+	case CapabilityEncodeJSON:
+		return "EncodeJSON"
+	case CapabilityDecodeJSON:
+		return "DecodeJSON"
+	case CapabilityFormatJSON:
+		return "FormatJSON"
 	default:
 		return "<unknown>"
 	}
 }
 
 // Capabilities holds several unitary capability flags
-type Capabilities uint8
+type Capabilities uint16
 
 // Has some capability flag enabled.
 func (c Capabilities) Has(capability Capability) bool {
@@ -53,6 +65,10 @@ func (c Capabilities) String() string {
 		CapabilityOrderedMarshalJSON,
 		CapabilityOrderedUnmarshalJSON,
 		CapabilityOrderedMap,
+		CapabilityTokenizeJSON,
+		CapabilityEncodeJSON,
+		CapabilityDecodeJSON,
+		CapabilityFormatJSON,
 	} {
 		if c.Has(capability) {
 			if !first {
@@ -68,13 +84,17 @@ func (c Capabilities) String() string {
 }
 
 const (
-	AllCapabilities Capabilities = Capabilities(uint8(CapabilityMarshalJSON) |
-		uint8(CapabilityUnmarshalJSON) |
-		uint8(CapabilityOrderedMarshalJSON) |
-		uint8(CapabilityOrderedUnmarshalJSON) |
-		uint8(CapabilityOrderedMap))
+	AllCapabilities Capabilities = Capabilities(uint16(CapabilityMarshalJSON) |
+		uint16(CapabilityUnmarshalJSON) |
+		uint16(CapabilityOrderedMarshalJSON) |
+		uint16(CapabilityOrderedUnmarshalJSON) |
+		uint16(CapabilityOrderedMap))
 
-	AllUnorderedCapabilities Capabilities = Capabilities(uint8(CapabilityMarshalJSON) | uint8(CapabilityUnmarshalJSON))
+	AllUnorderedCapabilities Capabilities = Capabilities(uint16(CapabilityMarshalJSON) | uint16(CapabilityUnmarshalJSON))
+
+	AllStreamingCapabilities Capabilities = Capabilities(uint16(CapabilityTokenizeJSON) |
+		uint16(CapabilityEncodeJSON) |
+		uint16(CapabilityDecodeJSON))
 )
 
 // RegistryEntry describes how any given adapter registers its capabilities to the [Registrar].
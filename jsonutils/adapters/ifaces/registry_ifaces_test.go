@@ -35,6 +35,22 @@ func TestRegistryIfaces(t *testing.T) {
 				in:       CapabilityOrderedMap,
 				expected: "OrderedMap",
 			},
+			{
+				in:       CapabilityTokenizeJSON,
+				expected: "TokenizeJSON",
+			},
+			{
+				in:       CapabilityEncodeJSON,
+				expected: "EncodeJSON",
+			},
+			{
+				in:       CapabilityDecodeJSON,
+				expected: "DecodeJSON",
+			},
+			{
+				in:       CapabilityFormatJSON,
+				expected: "FormatJSON",
+			},
 			{
 				in:       Capability(99),
 				expected: "<unknown>",
@@ -57,6 +73,14 @@ func TestRegistryIfaces(t *testing.T) {
 				in:       AllUnorderedCapabilities,
 				expected: "MarshalJSON|UnmarshalJSON",
 			},
+			{
+				in:       AllStreamingCapabilities,
+				expected: "TokenizeJSON|EncodeJSON|DecodeJSON",
+			},
+			{
+				in:       Capabilities(CapabilityFormatJSON),
+				expected: "FormatJSON",
+			},
 			{
 				in:       Capabilities(CapabilityMarshalJSON | CapabilityOrderedMap),
 				expected: "MarshalJSON|OrderedMap",
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package ifaces
+
+import "io"
+
+// TokenKind indicates the kind of a JSON [Token].
+type TokenKind uint8
+
+const (
+	TokenUndefined TokenKind = iota
+	TokenBeginObject
+	TokenEndObject
+	TokenBeginArray
+	TokenEndArray
+	TokenKey
+	TokenString
+	TokenNumber
+	TokenBool
+	TokenNull
+)
+
+func (k TokenKind) String() string {
+	switch k {
+	case TokenBeginObject:
+		return "BeginObject"
+	case TokenEndObject:
+		return "EndObject"
+	case TokenBeginArray:
+		return "BeginArray"
+	case TokenEndArray:
+		return "EndArray"
+	case TokenKey:
+		return "Key"
+	case TokenString:
+		return "String"
+	case TokenNumber:
+		return "Number"
+	case TokenBool:
+		return "Bool"
+	case TokenNull:
+		return "Null"
+	default:
+		return "<undefined>"
+	}
+}
+
+// Token is a JSON token read from a stream.
+//
+// Commas and colons are elided.
+type Token struct {
+	Kind TokenKind
+
+	// Text holds the unescaped value of a key or a string, the literal of a number,
+	// "true" or "false" for a boolean, "null", or the delimiter, e.g. "{".
+	Text string
+}
+
+func (t Token) String() string {
+	return t.Kind.String() + "(" + t.Text + ")"
+}
+
+// Tokenizer reads JSON tokens from a stream.
+//
+// Object keys are reported as [TokenKey], distinct from string values reported as [TokenString].
+//
+// A [Tokenizer] reads a stream of JSON values, one after another. At the end of the stream,
+// NextToken returns [io.EOF].
+type Tokenizer interface {
+	// NextToken returns the next JSON token in the stream.
+	NextToken() (Token, error)
+
+	// Redeem the [Tokenizer] when it comes from a pool.
+	//
+	// The [Tokenizer] must not be used after calling [Redeem].
+	Redeem()
+}
+
+// TokenizerAdapter knows how to read JSON tokens from a stream.
+//
+// It is an optional interface that an [Adapter] registered with the [CapabilityTokenizeJSON] capability implements.
+type TokenizerAdapter interface {
+	Poolable
+
+	NewTokenizer(io.Reader) Tokenizer
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package ifaces
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+)
+
+func TestTokens(t *testing.T) {
+	t.Run("token kind should be a Stringer for debugging and error formatting purpose", func(t *testing.T) {
+		for kind, expected := range map[TokenKind]string{
+			TokenBeginObject: "BeginObject",
+			TokenEndObject:   "EndObject",
+			TokenBeginArray:  "BeginArray",
+			TokenEndArray:    "EndArray",
+			TokenKey:         "Key",
+			TokenString:      "String",
+			TokenNumber:      "Number",
+			TokenBool:        "Bool",
+			TokenNull:        "Null",
+			TokenUndefined:   "<undefined>",
+		} {
+			assert.EqualT(t, expected, kind.String())
+		}
+	})
+
+	t.Run("token should be a Stringer for debugging and error formatting purpose", func(t *testing.T) {
+		assert.EqualT(t, "Key($ref)", Token{Kind: TokenKey, Text: "$ref"}.String())
+	})
+}
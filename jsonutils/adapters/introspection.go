@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package adapters
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+)
+
+// capabilities lists the capabilities served by a [Registrar].
+var capabilities = []ifaces.Capability{
+	ifaces.CapabilityMarshalJSON,
+	ifaces.CapabilityUnmarshalJSON,
+	ifaces.CapabilityOrderedMarshalJSON,
+	ifaces.CapabilityOrderedUnmarshalJSON,
+	ifaces.CapabilityOrderedMap,
+	ifaces.CapabilityTokenizeJSON,
+	ifaces.CapabilityEncodeJSON,
+	ifaces.CapabilityDecodeJSON,
+	ifaces.CapabilityFormatJSON,
+}
+
+// Route describes how a [Registrar] has routed the lookup of an adapter for some capability and type of value.
+type Route struct {
+	Capability ifaces.Capability
+	Type       reflect.Type
+
+	// Who identifies the selected [ifaces.RegistryEntry], or is empty when no registered adapter supports this value.
+	Who string
+
+	// Cached tells if the route comes from the cache of the [Registrar].
+	Cached bool
+}
+
+func (r Route) String() string {
+	who := r.Who
+	if who == "" {
+		who = "<none>"
+	}
+
+	var cached string
+	if r.Cached {
+		cached = " (cached)"
+	}
+
+	return fmt.Sprintf("%v for %v: %s%s", r.Capability, r.Type, who, cached)
+}
+
+// Stats holds counters about the lookups of adapters in a [Registrar].
+type Stats struct {
+	// CacheHits counts the lookups served by the cache.
+	CacheHits uint64
+
+	// CacheMisses counts the lookups not found in the cache, which went through the registered entries.
+	CacheMisses uint64
+
+	// Uncached counts the lookups that didn't consult the cache,
+	// because at most one entry is registered for their capability.
+	Uncached uint64
+}
+
+// Trial tells if a registered entry supports the capability and type of value of an [Explanation].
+type Trial struct {
+	Who       string
+	Supported bool
+}
+
+// Explanation tells how a [Registrar] routes the lookup of an adapter for some capability and type of value.
+type Explanation struct {
+	Capability ifaces.Capability
+	Type       reflect.Type
+
+	// Tried lists the registered entries tried in turn without the cache, until one supports the value.
+	Tried []Trial
+
+	// Cached tells if the route is served by the cache of the [Registrar].
+	//
+	// A cached route is not updated when new adapters are registered. See [Registrar.ClearCache].
+	Cached bool
+
+	// Selected identifies the [ifaces.RegistryEntry] that serves the lookup, or is empty when no registered adapter
+	// supports this value.
+	Selected string
+}
+
+func (e Explanation) String() string {
+	var w strings.Builder
+
+	w.WriteString(Route{Capability: e.Capability, Type: e.Type, Who: e.Selected, Cached: e.Cached}.String())
+	for _, trial := range e.Tried {
+		supported := "not supported"
+		if trial.Supported {
+			supported = "supported"
+		}
+
+		fmt.Fprintf(&w, "\n  - %s: %s", trial.Who, supported)
+	}
+
+	return w.String()
+}
+
+// Entries returns the registered entries, in the order in which they are looked up.
+//
+// Every returned entry holds a single capability: an adapter registered for several capabilities
+// appears once for each of them.
+func (r *Registrar) Entries() []ifaces.RegistryEntry {
+	r.gmx.RLock()
+	defer r.gmx.RUnlock()
+
+	var entries []ifaces.RegistryEntry
+	for _, capability := range capabilities {
+		reg, _ := r.registryFor(capability)
+		for _, entry := range reg {
+			entries = append(entries, *entry)
+		}
+	}
+
+	return entries
+}
+
+// Explain tells how the lookup of an adapter for this capability and this type of value is routed,
+// i.e. which registered entries are tried and whether they support the value.
+//
+// Explain neither updates the cache nor the [Stats], and doesn't call the debug hook.
+func (r *Registrar) Explain(capability ifaces.Capability, value any) Explanation {
+	r.gmx.RLock()
+	defer r.gmx.RUnlock()
+
+	reg, cache := r.registryFor(capability)
+	explanation := Explanation{
+		Capability: capability,
+		Type:       reflect.TypeOf(value),
+	}
+
+	for _, entry := range reg {
+		supported := entry.Support(capability, value)
+		explanation.Tried = append(explanation.Tried, Trial{Who: entry.Who, Supported: supported})
+
+		if supported {
+			explanation.Selected = entry.Who
+
+			break
+		}
+	}
+
+	if len(reg) > 1 {
+		if entry, ok := cache[explanation.Type]; ok {
+			explanation.Cached = true
+			explanation.Selected = entry.Who
+		}
+	}
+
+	return explanation
+}
+
+// Stats returns the counters of the lookups of adapters since the [Registrar] was created or [Registrar.Reset].
+func (r *Registrar) Stats() Stats {
+	return Stats{
+		CacheHits:   r.cacheHits.Load(),
+		CacheMisses: r.cacheMisses.Load(),
+		Uncached:    r.uncached.Load(),
+	}
+}
+
+// SetDebugHook sets a function called with every [Route] decided by the [Registrar], e.g. to log it.
+//
+// A nil hook disables this. The hook is kept when the [Registrar] is [Registrar.Reset].
+func (r *Registrar) SetDebugHook(hook func(Route)) {
+	if hook == nil {
+		r.debugHook.Store(nil)
+
+		return
+	}
+
+	r.debugHook.Store(&hook)
+}
+
+func (r *Registrar) route(capability ifaces.Capability, value any, entry *ifaces.RegistryEntry, cached bool) {
+	hook := r.debugHook.Load()
+	if hook == nil {
+		return
+	}
+
+	route := Route{
+		Capability: capability,
+		Type:       reflect.TypeOf(value),
+		Cached:     cached,
+	}
+	if entry != nil {
+		route.Who = entry.Who
+	}
+
+	(*hook)(route)
+}
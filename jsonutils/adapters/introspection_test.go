@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package adapters
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+	stdlib "github.com/go-openapi/swag/jsonutils/adapters/stdlib/json"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestRegistryIntrospection(t *testing.T) {
+	t.Parallel()
+
+	const (
+		mock2     = "github.com/go-openapi/swag/jsonutils/adapters.MockAdapter2"
+		stdlibWho = "github.com/go-openapi/swag/jsonutils/adapters/stdlib/json.Adapter"
+	)
+
+	reg := NewRegistrar()
+	register2(reg)
+
+	var routes []Route
+	reg.SetDebugHook(func(route Route) {
+		routes = append(routes, route)
+	})
+
+	t.Run("should list entries in lookup order", func(t *testing.T) {
+		entries := reg.Entries()
+		require.Len(t, entries, 14) // 9 capabilities for stdlib, 5 for the mock
+
+		assert.EqualT(t, mock2, entries[0].Who)
+		assert.EqualT(t, ifaces.Capabilities(ifaces.CapabilityMarshalJSON), entries[0].What)
+		assert.EqualT(t, stdlibWho, entries[1].Who)
+		assert.EqualT(t, ifaces.Capabilities(ifaces.CapabilityMarshalJSON), entries[1].What)
+
+		last := entries[len(entries)-1]
+		assert.EqualT(t, stdlibWho, last.Who)
+		assert.EqualT(t, ifaces.Capabilities(ifaces.CapabilityFormatJSON), last.What)
+	})
+
+	t.Run("should explain the routing of a lookup", func(t *testing.T) {
+		explanation := reg.Explain(ifaces.CapabilityMarshalJSON, supportedType{})
+		assert.Equal(t, Explanation{
+			Capability: ifaces.CapabilityMarshalJSON,
+			Type:       reflect.TypeOf(supportedType{}),
+			Tried:      []Trial{{Who: mock2, Supported: true}},
+			Selected:   mock2,
+		}, explanation)
+
+		explanation = reg.Explain(ifaces.CapabilityMarshalJSON, 1)
+		assert.Equal(t, []Trial{{Who: mock2, Supported: false}, {Who: stdlibWho, Supported: true}}, explanation.Tried)
+		assert.EqualT(t, stdlibWho, explanation.Selected)
+		assert.FalseT(t, explanation.Cached)
+		assert.EqualT(t,
+			"MarshalJSON for int: "+stdlibWho+"\n  - "+mock2+": not supported\n  - "+stdlibWho+": supported",
+			explanation.String(),
+		)
+
+		t.Run("explaining should not route anything", func(t *testing.T) {
+			assert.Empty(t, routes)
+			assert.EqualT(t, Stats{}, reg.Stats())
+			assert.Empty(t, reg.marshalerCache)
+		})
+	})
+
+	t.Run("should count lookups and call the debug hook", func(t *testing.T) {
+		for range 2 {
+			adapter := reg.AdapterFor(ifaces.CapabilityMarshalJSON, 1)
+			require.NotNil(t, adapter)
+			adapter.Redeem()
+		}
+
+		assert.EqualT(t, Stats{CacheHits: 1, CacheMisses: 1}, reg.Stats())
+		require.Len(t, routes, 2)
+		assert.EqualT(t, Route{Capability: ifaces.CapabilityMarshalJSON, Type: reflect.TypeOf(1), Who: stdlibWho}, routes[0])
+		assert.EqualT(t, Route{Capability: ifaces.CapabilityMarshalJSON, Type: reflect.TypeOf(1), Who: stdlibWho, Cached: true}, routes[1])
+		assert.EqualT(t, "MarshalJSON for int: "+stdlibWho+" (cached)", routes[1].String())
+
+		t.Run("should explain a cached route", func(t *testing.T) {
+			explanation := reg.Explain(ifaces.CapabilityMarshalJSON, 1)
+			assert.TrueT(t, explanation.Cached)
+			assert.EqualT(t, stdlibWho, explanation.Selected)
+		})
+	})
+
+	t.Run("should report lookups without a supporting adapter", func(t *testing.T) {
+		routes = nil
+		reg.tokenizerRegistry = reg.tokenizerRegistry[:0]
+
+		require.Nil(t, reg.AdapterFor(ifaces.CapabilityTokenizeJSON, 1))
+		require.Len(t, routes, 1)
+		assert.EqualT(t, "TokenizeJSON for int: <none>", routes[0].String())
+
+		explanation := reg.Explain(ifaces.CapabilityTokenizeJSON, 1)
+		assert.Empty(t, explanation.Tried)
+		assert.EqualT(t, "", explanation.Selected)
+	})
+
+	t.Run("should reset counters, but keep the debug hook", func(t *testing.T) {
+		routes = nil
+		reg.Reset()
+		assert.EqualT(t, Stats{}, reg.Stats())
+
+		adapter := reg.AdapterFor(ifaces.CapabilityMarshalJSON, 1)
+		require.NotNil(t, adapter)
+		_, isStdLib := adapter.(*stdlib.Adapter)
+		require.TrueT(t, isStdLib)
+		adapter.Redeem()
+
+		assert.Len(t, routes, 1)
+		assert.EqualT(t, Stats{Uncached: 1}, reg.Stats())
+
+		t.Run("should disable the debug hook", func(t *testing.T) {
+			reg.SetDebugHook(nil)
+			adapter := reg.AdapterFor(ifaces.CapabilityMarshalJSON, 1)
+			require.NotNil(t, adapter)
+			adapter.Redeem()
+
+			assert.Len(t, routes, 1)
+		})
+	})
+}
+
+func TestRegistryStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with the default registrar, lookups should not consult the cache", func(t *testing.T) {
+		reg := NewRegistrar()
+
+		for range 3 {
+			adapter := reg.AdapterFor(ifaces.CapabilityMarshalJSON, 1)
+			require.NotNil(t, adapter)
+			adapter.Redeem()
+		}
+
+		assert.EqualT(t, Stats{Uncached: 3}, reg.Stats())
+	})
+
+	t.Run("with several adapters registered, lookups should consult the cache", func(t *testing.T) {
+		reg := NewRegistrar()
+		register2(reg)
+
+		for range 3 {
+			adapter := reg.AdapterFor(ifaces.CapabilityMarshalJSON, 1)
+			require.NotNil(t, adapter)
+			adapter.Redeem()
+		}
+
+		// TokenizeJSON is only served by the default adapter
+		adapter := reg.AdapterFor(ifaces.CapabilityTokenizeJSON, 1)
+		require.NotNil(t, adapter)
+		adapter.Redeem()
+
+		assert.EqualT(t, Stats{CacheHits: 2, CacheMisses: 1, Uncached: 1}, reg.Stats())
+
+		reg.Reset()
+		assert.EqualT(t, Stats{}, reg.Stats())
+	})
+}
@@ -5,9 +5,11 @@ package adapters
 
 import (
 	"fmt"
+	"io"
 	"reflect"
 	"slices"
 	"sync"
+	"sync/atomic"
 
 	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
 	stdlib "github.com/go-openapi/swag/jsonutils/adapters/stdlib/json"
@@ -35,13 +37,21 @@ type registry []*ifaces.RegistryEntry
 
 // Registrar holds registered [ifaces.Adapters] for different serialization capabilities.
 //
+// Registered entries are kept in a separate list for every [ifaces.Capability]. The lookup of an adapter
+// for a capability only goes through the entries registered for this capability.
+//
 // Internally, it maintains a cache for data types that favor a given adapter.
+// The cache is only consulted when several entries are registered for a capability.
 type Registrar struct {
 	marshalerRegistry          registry
 	unmarshalerRegistry        registry
 	orderedMarshalerRegistry   registry
 	orderedUnmarshalerRegistry registry
 	orderedMapRegistry         registry
+	tokenizerRegistry          registry
+	encoderRegistry            registry
+	decoderRegistry            registry
+	formatterRegistry          registry
 
 	gmx sync.RWMutex
 
@@ -51,6 +61,16 @@ type Registrar struct {
 	orderedMarshalerCache   map[reflect.Type]*ifaces.RegistryEntry
 	orderedUnmarshalerCache map[reflect.Type]*ifaces.RegistryEntry
 	orderedMapCache         map[reflect.Type]*ifaces.RegistryEntry
+	tokenizerCache          map[reflect.Type]*ifaces.RegistryEntry
+	encoderCache            map[reflect.Type]*ifaces.RegistryEntry
+	decoderCache            map[reflect.Type]*ifaces.RegistryEntry
+	formatterCache          map[reflect.Type]*ifaces.RegistryEntry
+
+	// introspection
+	cacheHits   atomic.Uint64
+	cacheMisses atomic.Uint64
+	uncached    atomic.Uint64
+	debugHook   atomic.Pointer[func(Route)]
 }
 
 func NewRegistrar() *Registrar {
@@ -61,12 +81,20 @@ func NewRegistrar() *Registrar {
 	r.orderedMarshalerRegistry = make(registry, 0, 1)
 	r.orderedUnmarshalerRegistry = make(registry, 0, 1)
 	r.orderedMapRegistry = make(registry, 0, 1)
+	r.tokenizerRegistry = make(registry, 0, 1)
+	r.encoderRegistry = make(registry, 0, 1)
+	r.decoderRegistry = make(registry, 0, 1)
+	r.formatterRegistry = make(registry, 0, 1)
 
 	r.marshalerCache = make(map[reflect.Type]*ifaces.RegistryEntry)
 	r.unmarshalerCache = make(map[reflect.Type]*ifaces.RegistryEntry)
 	r.orderedMarshalerCache = make(map[reflect.Type]*ifaces.RegistryEntry)
 	r.orderedUnmarshalerCache = make(map[reflect.Type]*ifaces.RegistryEntry)
 	r.orderedMapCache = make(map[reflect.Type]*ifaces.RegistryEntry)
+	r.tokenizerCache = make(map[reflect.Type]*ifaces.RegistryEntry)
+	r.encoderCache = make(map[reflect.Type]*ifaces.RegistryEntry)
+	r.decoderCache = make(map[reflect.Type]*ifaces.RegistryEntry)
+	r.formatterCache = make(map[reflect.Type]*ifaces.RegistryEntry)
 
 	defaultRegistered(r)
 
@@ -89,8 +117,16 @@ func (r *Registrar) Reset() {
 	r.orderedMarshalerRegistry = r.orderedMarshalerRegistry[:0]
 	r.orderedUnmarshalerRegistry = r.orderedUnmarshalerRegistry[:0]
 	r.orderedMapRegistry = r.orderedMapRegistry[:0]
+	r.tokenizerRegistry = r.tokenizerRegistry[:0]
+	r.encoderRegistry = r.encoderRegistry[:0]
+	r.decoderRegistry = r.decoderRegistry[:0]
+	r.formatterRegistry = r.formatterRegistry[:0]
 	r.gmx.Unlock()
 
+	r.cacheHits.Store(0)
+	r.cacheMisses.Store(0)
+	r.uncached.Store(0)
+
 	defaultRegistered(r)
 }
 
@@ -122,6 +158,26 @@ func (r *Registrar) RegisterFor(entry ifaces.RegistryEntry) {
 		e.What &= ifaces.Capabilities(ifaces.CapabilityOrderedMap)
 		r.orderedMapRegistry = slices.Insert(r.orderedMapRegistry, 0, &e)
 	}
+	if entry.What.Has(ifaces.CapabilityTokenizeJSON) {
+		e := entry
+		e.What &= ifaces.Capabilities(ifaces.CapabilityTokenizeJSON)
+		r.tokenizerRegistry = slices.Insert(r.tokenizerRegistry, 0, &e)
+	}
+	if entry.What.Has(ifaces.CapabilityEncodeJSON) {
+		e := entry
+		e.What &= ifaces.Capabilities(ifaces.CapabilityEncodeJSON)
+		r.encoderRegistry = slices.Insert(r.encoderRegistry, 0, &e)
+	}
+	if entry.What.Has(ifaces.CapabilityDecodeJSON) {
+		e := entry
+		e.What &= ifaces.Capabilities(ifaces.CapabilityDecodeJSON)
+		r.decoderRegistry = slices.Insert(r.decoderRegistry, 0, &e)
+	}
+	if entry.What.Has(ifaces.CapabilityFormatJSON) {
+		e := entry
+		e.What &= ifaces.Capabilities(ifaces.CapabilityFormatJSON)
+		r.formatterRegistry = slices.Insert(r.formatterRegistry, 0, &e)
+	}
 	r.gmx.Unlock()
 }
 
@@ -144,20 +200,39 @@ func (r *Registrar) clearCache() {
 	clear(r.orderedMarshalerCache)
 	clear(r.orderedUnmarshalerCache)
 	clear(r.orderedMapCache)
+	clear(r.tokenizerCache)
+	clear(r.encoderCache)
+	clear(r.decoderCache)
+	clear(r.formatterCache)
 }
 
 func (r *Registrar) findFirstFor(capability ifaces.Capability, value any) *ifaces.RegistryEntry {
+	reg, cache := r.registryFor(capability)
+
+	return r.findFirstInRegistryFor(reg, cache, capability, value)
+}
+
+// registryFor returns the registry and the cache for a capability.
+func (r *Registrar) registryFor(capability ifaces.Capability) (registry, map[reflect.Type]*ifaces.RegistryEntry) {
 	switch capability {
 	case ifaces.CapabilityMarshalJSON:
-		return r.findFirstInRegistryFor(r.marshalerRegistry, r.marshalerCache, capability, value)
+		return r.marshalerRegistry, r.marshalerCache
 	case ifaces.CapabilityUnmarshalJSON:
-		return r.findFirstInRegistryFor(r.unmarshalerRegistry, r.unmarshalerCache, capability, value)
+		return r.unmarshalerRegistry, r.unmarshalerCache
 	case ifaces.CapabilityOrderedMarshalJSON:
-		return r.findFirstInRegistryFor(r.orderedMarshalerRegistry, r.orderedMarshalerCache, capability, value)
+		return r.orderedMarshalerRegistry, r.orderedMarshalerCache
 	case ifaces.CapabilityOrderedUnmarshalJSON:
-		return r.findFirstInRegistryFor(r.orderedUnmarshalerRegistry, r.orderedUnmarshalerCache, capability, value)
+		return r.orderedUnmarshalerRegistry, r.orderedUnmarshalerCache
 	case ifaces.CapabilityOrderedMap:
-		return r.findFirstInRegistryFor(r.orderedMapRegistry, r.orderedMapCache, capability, value)
+		return r.orderedMapRegistry, r.orderedMapCache
+	case ifaces.CapabilityTokenizeJSON:
+		return r.tokenizerRegistry, r.tokenizerCache
+	case ifaces.CapabilityEncodeJSON:
+		return r.encoderRegistry, r.encoderCache
+	case ifaces.CapabilityDecodeJSON:
+		return r.decoderRegistry, r.decoderCache
+	case ifaces.CapabilityFormatJSON:
+		return r.formatterRegistry, r.formatterCache
 	default:
 		panic(fmt.Errorf("unsupported capability %d: %w", capability, ErrRegistry))
 	}
@@ -169,8 +244,16 @@ func (r *Registrar) findFirstInRegistryFor(reg registry, cache map[reflect.Type]
 		if entry, ok := cache[reflect.TypeOf(value)]; ok {
 			// cache hit
 			r.gmx.RUnlock()
+			r.cacheHits.Add(1)
+			r.route(capability, value, entry, true)
+
 			return entry
 		}
+
+		r.cacheMisses.Add(1)
+	} else {
+		// with a single entry (or none), the lookup is as fast as the cache
+		r.uncached.Add(1)
 	}
 
 	for _, entry := range reg {
@@ -185,11 +268,14 @@ func (r *Registrar) findFirstInRegistryFor(reg registry, cache map[reflect.Type]
 		cache[reflect.TypeOf(value)] = entry
 		r.gmx.Unlock()
 
+		r.route(capability, value, entry, false)
+
 		return entry
 	}
 
 	// no adapter found
 	r.gmx.RUnlock()
+	r.route(capability, value, nil, false)
 
 	return nil
 }
@@ -214,6 +300,82 @@ func OrderedUnmarshalAdapterFor(value ifaces.SetOrdered) ifaces.OrderedUnmarshal
 	return Registry.AdapterFor(ifaces.CapabilityOrderedUnmarshalJSON, value)
 }
 
+// EncodeAdapterFor returns the first adapter that knows how to Encode this type of value to a stream.
+//
+// It returns nil if no registered adapter supports the [ifaces.CapabilityEncodeJSON] capability for this value.
+func EncodeAdapterFor(value any) ifaces.EncodeAdapter {
+	adapter := Registry.AdapterFor(ifaces.CapabilityEncodeJSON, value)
+	if adapter == nil {
+		return nil
+	}
+
+	encoder, ok := adapter.(ifaces.EncodeAdapter)
+	if !ok {
+		adapter.Redeem()
+
+		return nil
+	}
+
+	return encoder
+}
+
+// DecodeAdapterFor returns the first adapter that knows how to Decode this type of value from a stream.
+//
+// It returns nil if no registered adapter supports the [ifaces.CapabilityDecodeJSON] capability for this value.
+func DecodeAdapterFor(value any) ifaces.DecodeAdapter {
+	adapter := Registry.AdapterFor(ifaces.CapabilityDecodeJSON, value)
+	if adapter == nil {
+		return nil
+	}
+
+	decoder, ok := adapter.(ifaces.DecodeAdapter)
+	if !ok {
+		adapter.Redeem()
+
+		return nil
+	}
+
+	return decoder
+}
+
+// FormatMarshalAdapterFor returns the first adapter that knows how to marshal this type of value with formatting options.
+//
+// It returns nil if no registered adapter supports the [ifaces.CapabilityFormatJSON] capability for this value.
+func FormatMarshalAdapterFor(value any) ifaces.FormatMarshalAdapter {
+	adapter := Registry.AdapterFor(ifaces.CapabilityFormatJSON, value)
+	if adapter == nil {
+		return nil
+	}
+
+	formatter, ok := adapter.(ifaces.FormatMarshalAdapter)
+	if !ok {
+		adapter.Redeem()
+
+		return nil
+	}
+
+	return formatter
+}
+
+// TokenizerAdapterFor returns the first adapter that knows how to read JSON tokens from this reader.
+//
+// It returns nil if no registered adapter supports the [ifaces.CapabilityTokenizeJSON] capability for this reader.
+func TokenizerAdapterFor(r io.Reader) ifaces.TokenizerAdapter {
+	adapter := Registry.AdapterFor(ifaces.CapabilityTokenizeJSON, r)
+	if adapter == nil {
+		return nil
+	}
+
+	tokenizer, ok := adapter.(ifaces.TokenizerAdapter)
+	if !ok {
+		adapter.Redeem()
+
+		return nil
+	}
+
+	return tokenizer
+}
+
 // NewOrderedMap provides the "ordered map" implementation provided by the registry.
 func NewOrderedMap(capacity int) ifaces.OrderedMap {
 	var v any
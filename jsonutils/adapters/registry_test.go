@@ -6,6 +6,7 @@ package adapters
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
@@ -376,6 +377,66 @@ func TestGlobalRegistry(t *testing.T) {
 			_, isStdLib := orderedMap.(*stdlib.MapSlice)
 			require.TrueT(t, isStdLib)
 		})
+
+		t.Run("should resolve to the stdlib adapter for EncodeJSON", func(t *testing.T) {
+			var value any
+			adp := EncodeAdapterFor(value)
+			require.NotNil(t, adp)
+			defer adp.Redeem()
+
+			_, isStdLib := adp.(*stdlib.Adapter)
+			require.TrueT(t, isStdLib)
+		})
+
+		t.Run("should resolve to the stdlib adapter for DecodeJSON", func(t *testing.T) {
+			var value any
+			adp := DecodeAdapterFor(value)
+			require.NotNil(t, adp)
+			defer adp.Redeem()
+
+			_, isStdLib := adp.(*stdlib.Adapter)
+			require.TrueT(t, isStdLib)
+		})
+
+		t.Run("should resolve to the stdlib adapter for FormatJSON", func(t *testing.T) {
+			var value any
+			adp := FormatMarshalAdapterFor(value)
+			require.NotNil(t, adp)
+			defer adp.Redeem()
+
+			_, isStdLib := adp.(*stdlib.Adapter)
+			require.TrueT(t, isStdLib)
+		})
+
+		t.Run("should resolve to the stdlib adapter for TokenizeJSON", func(t *testing.T) {
+			adp := TokenizerAdapterFor(strings.NewReader(`{}`))
+			require.NotNil(t, adp)
+			defer adp.Redeem()
+
+			_, isStdLib := adp.(*stdlib.Adapter)
+			require.TrueT(t, isStdLib)
+		})
+	})
+}
+
+func TestRegistryTokenizer(t *testing.T) {
+	reg := NewRegistrar()
+
+	t.Run("should cache the route for this type of reader", func(t *testing.T) {
+		value := strings.NewReader(`{}`)
+		adapter := reg.AdapterFor(ifaces.CapabilityTokenizeJSON, value)
+		require.NotNil(t, adapter)
+		defer adapter.Redeem()
+
+		require.MapContainsT(t, reg.tokenizerCache, reflect.TypeOf(value))
+	})
+
+	t.Run("should not find an adapter after the registry is emptied", func(t *testing.T) {
+		reg.Reset()
+		reg.tokenizerRegistry = reg.tokenizerRegistry[:0]
+
+		adapter := reg.AdapterFor(ifaces.CapabilityTokenizeJSON, strings.NewReader(`{}`))
+		require.Nil(t, adapter)
 	})
 }
 
@@ -13,6 +13,9 @@ import (
 
 const sensibleBufferSize = 8192
 
+// flushThreshold is the size of the buffered output above which [Adapter.Encode] writes it to its output stream.
+const flushThreshold = sensibleBufferSize
+
 type jsonError string
 
 func (e jsonError) Error() string {
@@ -127,6 +130,8 @@ func (a *Adapter) orderedMarshal(w *jwriter, value ifaces.Ordered, depth int) {
 		default:
 			w.Raw(stdjson.Marshal(v))
 		}
+
+		w.flush()
 	}
 
 	w.RawByte('}')
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+	"github.com/go-openapi/swag/typeutils"
+)
+
+var _ ifaces.FormatMarshalAdapter = &Adapter{}
+
+// FormatMarshal marshals a value as JSON, with the layout described by [ifaces.FormatOptions].
+//
+// Values that implement [ifaces.Ordered] are indented in a single pass, with the order of keys maintained.
+func (a *Adapter) FormatMarshal(value any, format ifaces.FormatOptions) ([]byte, error) {
+	w, redeem := poolOfWriters.BorrowWithRedeem()
+	defer redeem()
+	w.setBuf()
+
+	if ordered, isOrdered := value.(ifaces.Ordered); isOrdered {
+		a.formatOrdered(w, ordered, format, 1)
+	} else {
+		w.encodeWith(value, format.Prefix, format.Indent, format.EscapeHTML)
+	}
+
+	if format.TrailingNewline {
+		w.RawByte('\n')
+	}
+
+	return w.BuildBytes()
+}
+
+// formatOrdered is like orderedMarshal, with the layout described by format.
+func (a *Adapter) formatOrdered(w *jwriter, value ifaces.Ordered, format ifaces.FormatOptions, depth int) {
+	if typeutils.IsNil(value) {
+		w.RawString("null")
+
+		return
+	}
+
+	if !a.checkFormatDepth(w, depth) {
+		return
+	}
+
+	w.RawByte('{')
+	first := true
+	for k, v := range value.OrderedItems() {
+		if first {
+			first = false
+		} else {
+			w.RawByte(',')
+		}
+
+		formatNewline(w, format, depth)
+		w.encodeWith(k, "", "", format.EscapeHTML)
+		w.RawByte(':')
+		if format.IsIndented() {
+			w.RawByte(' ')
+		}
+
+		a.formatValue(w, v, format, depth)
+	}
+
+	if !first {
+		formatNewline(w, format, depth-1)
+	}
+
+	w.RawByte('}')
+}
+
+// formatArray lays out arrays, so ordered maps nested in arrays are formatted in the same pass.
+func (a *Adapter) formatArray(w *jwriter, value []any, format ifaces.FormatOptions, depth int) {
+	if value == nil {
+		w.RawString("null")
+
+		return
+	}
+
+	if !a.checkFormatDepth(w, depth) {
+		return
+	}
+
+	w.RawByte('[')
+	for i, v := range value {
+		if i > 0 {
+			w.RawByte(',')
+		}
+
+		formatNewline(w, format, depth)
+		a.formatValue(w, v, format, depth)
+	}
+
+	if len(value) > 0 {
+		formatNewline(w, format, depth-1)
+	}
+
+	w.RawByte(']')
+}
+
+func (a *Adapter) formatValue(w *jwriter, value any, format ifaces.FormatOptions, depth int) {
+	switch val := value.(type) {
+	case ifaces.Ordered:
+		a.formatOrdered(w, val, format, depth+1)
+	case []any:
+		a.formatArray(w, val, format, depth+1)
+	default:
+		w.encodeWith(value, format.Prefix+strings.Repeat(format.Indent, depth), format.Indent, format.EscapeHTML)
+	}
+}
+
+func (a *Adapter) checkFormatDepth(w *jwriter, depth int) bool {
+	if maxDepth := a.maxDepth(); depth > maxDepth {
+		w.SetErr(fmt.Errorf("maximum nesting depth of %d exceeded: %w", maxDepth, ErrStdlib))
+
+		return false
+	}
+
+	return true
+}
+
+// formatNewline starts a new line of indented JSON, at some nesting level.
+func formatNewline(w *jwriter, format ifaces.FormatOptions, depth int) {
+	if !format.IsIndented() {
+		return
+	}
+
+	w.RawByte('\n')
+	w.RawString(format.Prefix)
+	w.RawString(strings.Repeat(format.Indent, depth))
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+	fixtures "github.com/go-openapi/swag/jsonutils/fixtures_test"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestAdapterFormat(t *testing.T) {
+	const reasonableCapacity = 10
+	a := BorrowAdapter()
+	defer func() {
+		RedeemAdapter(a)
+	}()
+
+	indented := ifaces.FormatOptions{Prefix: ">", Indent: "\t", EscapeHTML: true}
+
+	harness := fixtures.NewHarness(t)
+	harness.Init()
+
+	for name, test := range harness.AllTests(
+		fixtures.WithExcludePattern(regexp.MustCompile(`^with null value$`)),
+	) {
+		if test.ExpectError() {
+			continue
+		}
+
+		t.Run(name, func(t *testing.T) {
+			value := a.NewOrderedMap(reasonableCapacity)
+			require.NoError(t, a.Unmarshal(test.JSONBytes(), value))
+
+			compact, err := a.OrderedMarshal(value)
+			require.NoError(t, err)
+
+			t.Run("should FormatMarshal like json.Indent", func(t *testing.T) {
+				var expected bytes.Buffer
+				require.NoError(t, stdjson.Indent(&expected, compact, indented.Prefix, indented.Indent))
+
+				formatted, err := a.FormatMarshal(value, indented)
+				require.NoError(t, err)
+				assert.EqualT(t, expected.String(), string(formatted))
+			})
+
+			t.Run("should FormatMarshal like OrderedMarshal with default options", func(t *testing.T) {
+				formatted, err := a.FormatMarshal(value, ifaces.FormatOptions{EscapeHTML: true})
+				require.NoError(t, err)
+				assert.EqualT(t, string(compact), string(formatted))
+			})
+		})
+	}
+
+	t.Run("should FormatMarshal like json.MarshalIndent", func(t *testing.T) {
+		value := map[string]any{"a": []any{1, map[string]any{}}, "b": "<html>", "c": map[string]any{"d": []any{}}}
+		expected, err := stdjson.MarshalIndent(value, "", "  ")
+		require.NoError(t, err)
+
+		formatted, err := a.FormatMarshal(value, ifaces.FormatOptions{Indent: "  ", EscapeHTML: true})
+		require.NoError(t, err)
+		assert.EqualT(t, string(expected), string(formatted))
+	})
+
+	t.Run("should FormatMarshal ordered values", func(t *testing.T) {
+		value := MapSlice{
+			{Key: "z", Value: MapSlice{{Key: "y", Value: []any{1, MapSlice{{Key: "x", Value: "<&>"}}}}}},
+			{Key: "<a>", Value: MapSlice{}},
+		}
+
+		formatted, err := a.FormatMarshal(value, ifaces.FormatOptions{Indent: "  ", TrailingNewline: true})
+		require.NoError(t, err)
+		assert.EqualT(t, strings.Join([]string{
+			`{`,
+			`  "z": {`,
+			`    "y": [`,
+			`      1,`,
+			`      {`,
+			`        "x": "<&>"`,
+			`      }`,
+			`    ]`,
+			`  },`,
+			`  "<a>": {}`,
+			`}`,
+			``,
+		}, "\n"), string(formatted))
+
+		formatted, err = a.FormatMarshal(value, ifaces.FormatOptions{EscapeHTML: true})
+		require.NoError(t, err)
+		assert.EqualT(t, `{"z":{"y":[1,{"x":"\u003c\u0026\u003e"}]},"\u003ca\u003e":{}}`, string(formatted))
+
+		var nilMap MapSlice
+		formatted, err = a.FormatMarshal(nilMap, indented)
+		require.NoError(t, err)
+		assert.EqualT(t, "null", string(formatted))
+	})
+
+	t.Run("should report errors", func(t *testing.T) {
+		_, err := a.FormatMarshal(MapSlice{{Key: "a", Value: func() {}}}, indented)
+		require.Error(t, err)
+
+		_, err = a.FormatMarshal(func() {}, indented)
+		require.Error(t, err)
+
+		shallow := NewAdapter(WithMaxNestingDepth(1))
+		_, err = shallow.FormatMarshal(MapSlice{{Key: "a", Value: MapSlice{}}}, indented)
+		require.ErrorIs(t, err, ErrStdlib)
+	})
+}
@@ -18,7 +18,7 @@ func Register(dispatcher ifaces.Registrar, opts ...Option) {
 	dispatcher.RegisterFor(
 		ifaces.RegistryEntry{
 			Who:  fmt.Sprintf("%s.%s", t.PkgPath(), t.Name()),
-			What: ifaces.AllCapabilities,
+			What: ifaces.AllCapabilities | ifaces.AllStreamingCapabilities | ifaces.Capabilities(ifaces.CapabilityFormatJSON),
 			Constructor: func() ifaces.Adapter {
 				a := BorrowAdapter()
 				a.options = o
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	stdjson "encoding/json"
+	"io"
+
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+)
+
+var (
+	_ ifaces.EncodeAdapter = &Adapter{}
+	_ ifaces.DecodeAdapter = &Adapter{}
+)
+
+// Encode writes the JSON encoding of value to out.
+//
+// Values that implement [ifaces.Ordered] are written like [Adapter.OrderedMarshal] does:
+// the output is written to out in chunks, as the members of ordered maps are encoded.
+//
+// Other values are written like [Adapter.Marshal] does: the standard library
+// builds the whole output in memory before it is written to out.
+//
+// When an error is returned, part of the output may already have been written to out.
+func (a *Adapter) Encode(out io.Writer, value any) error {
+	w, redeem := poolOfWriters.BorrowWithRedeem()
+	defer redeem()
+	w.setBuf()
+	w.out = out
+
+	if ordered, isOrdered := value.(ifaces.Ordered); isOrdered {
+		a.orderedMarshal(w, ordered, 1)
+	} else {
+		w.encode(value)
+	}
+
+	return w.dumpTo(out)
+}
+
+// Decode reads the next JSON value from r and stores it in value.
+//
+// Values that implement [ifaces.SetOrdered] are read like [Adapter.OrderedUnmarshal] does.
+// Other values are read like [Adapter.Unmarshal] does.
+//
+// Like with [stdjson.Decoder], Decode may read data from r beyond the JSON value.
+func (a *Adapter) Decode(r io.Reader, value any) error {
+	dec := stdjson.NewDecoder(r)
+
+	ordered, isOrdered := value.(ifaces.SetOrdered)
+	if !isOrdered {
+		return dec.Decode(value)
+	}
+
+	var raw stdjson.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+
+	return a.OrderedUnmarshal(raw, ordered)
+}
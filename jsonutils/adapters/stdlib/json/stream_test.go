@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	fixtures "github.com/go-openapi/swag/jsonutils/fixtures_test"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestAdapterStream(t *testing.T) {
+	const reasonableCapacity = 10
+	a := BorrowAdapter()
+	defer func() {
+		RedeemAdapter(a)
+	}()
+
+	harness := fixtures.NewHarness(t)
+	harness.Init()
+
+	for name, test := range harness.AllTests(
+		// like with OrderedMarshal, a nil ordered map is encoded as an empty object.
+		fixtures.WithExcludePattern(regexp.MustCompile(`^with null value$`)),
+	) {
+		t.Run(name, func(t *testing.T) {
+			t.Run("should Decode JSON", func(t *testing.T) {
+				value := a.NewOrderedMap(reasonableCapacity)
+
+				if test.ExpectError() {
+					require.Error(t, a.Decode(bytes.NewReader(test.JSONBytes()), value))
+
+					return
+				}
+
+				require.NoError(t, a.Decode(bytes.NewReader(test.JSONBytes()), value))
+
+				t.Run("should Encode JSON with identical JSON", func(t *testing.T) {
+					var buf bytes.Buffer
+					require.NoError(t, a.Encode(&buf, value))
+
+					fixtures.JSONEqualOrderedBytes(t, test.JSONBytes(), buf.Bytes())
+				})
+			})
+		})
+	}
+
+	t.Run("should Encode like Marshal", func(t *testing.T) {
+		value := map[string]any{"a": []int{1, 2}, "b": "<html>"}
+		expected, err := a.Marshal(value)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, a.Encode(&buf, value))
+		assert.EqualT(t, string(expected), buf.String())
+	})
+
+	t.Run("should Decode like Unmarshal", func(t *testing.T) {
+		var value map[string]any
+		require.NoError(t, a.Decode(bytes.NewReader([]byte(`{"a":[1,2]}`)), &value))
+		assert.Equal(t, map[string]any{"a": []any{1.0, 2.0}}, value)
+	})
+
+	t.Run("should report errors when encoding", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.Error(t, a.Encode(&buf, func() {}))
+		assert.Zero(t, buf.Len())
+	})
+
+	t.Run("should report errors from the writer", func(t *testing.T) {
+		require.ErrorIs(t, a.Encode(failingWriter{}, []int{1}), errWrite)
+	})
+
+	t.Run("should Encode large ordered maps in chunks", func(t *testing.T) {
+		const members = 1000
+		value := make(MapSlice, 0, members)
+		for i := range members {
+			value = append(value, MapItem{Key: fmt.Sprintf("key%04d", i), Value: strings.Repeat("x", 32)})
+		}
+
+		expected, err := a.OrderedMarshal(value)
+		require.NoError(t, err)
+
+		var out chunksWriter
+		require.NoError(t, a.Encode(&out, value))
+		assert.EqualT(t, string(expected), out.String())
+		assert.GreaterT(t, len(out.chunks), 1)
+		for _, chunk := range out.chunks[:len(out.chunks)-1] {
+			assert.LessT(t, chunk, 2*flushThreshold)
+		}
+
+		require.ErrorIs(t, a.Encode(failingWriter{}, value), errWrite)
+	})
+
+	t.Run("should Decode without reading until EOF", func(t *testing.T) {
+		r := io.MultiReader(strings.NewReader(`{"a":1}`), iotest.ErrReader(errRead))
+
+		value := a.NewOrderedMap(reasonableCapacity)
+		require.NoError(t, a.Decode(r, value))
+		fixtures.JSONEqualOrderedBytes(t, []byte(`{"a":1}`), mustEncode(t, a, value))
+	})
+}
+
+func mustEncode(t *testing.T, a *Adapter, value any) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	require.NoError(t, a.Encode(&buf, value))
+
+	return buf.Bytes()
+}
+
+var errRead = errors.New("test read error")
+
+// chunksWriter records the size of every write.
+type chunksWriter struct {
+	bytes.Buffer
+
+	chunks []int
+}
+
+func (w *chunksWriter) Write(data []byte) (int, error) {
+	w.chunks = append(w.chunks, len(data))
+
+	return w.Buffer.Write(data)
+}
+
+var errWrite = errors.New("test write error")
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errWrite
+}
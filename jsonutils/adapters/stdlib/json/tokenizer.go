@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	stdjson "encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+)
+
+var (
+	_ ifaces.TokenizerAdapter = &Adapter{}
+	_ ifaces.Tokenizer        = &tokenizer{}
+)
+
+// NewTokenizer yields an [ifaces.Tokenizer] that reads JSON tokens from r.
+//
+// The [ifaces.Tokenizer] borrows a lexer from a pool: it should be redeemed once done.
+//
+// The nesting depth of containers is limited, like with [Adapter.OrderedUnmarshal]. See [WithMaxNestingDepth].
+func (a *Adapter) NewTokenizer(r io.Reader) ifaces.Tokenizer {
+	l, redeem := poolOfLexers.BorrowWithRedeem()
+	l.maxDepth = a.maxDepth()
+	l.dec = stdjson.NewDecoder(r)
+	l.dec.UseNumber()
+
+	return &tokenizer{
+		lexer:  l,
+		redeem: redeem,
+	}
+}
+
+// tokenizer drives the lexer over a stream and tells keys from string values.
+type tokenizer struct {
+	lexer      *jlexer
+	redeem     func()
+	containers []byte // stack of open containers, '{' or '['
+	expectKey  bool
+}
+
+func (t *tokenizer) NextToken() (ifaces.Token, error) {
+	l := t.lexer
+	if l == nil {
+		return ifaces.Token{}, fmt.Errorf("tokenizer used after it has been redeemed: %w", ErrStdlib)
+	}
+
+	if !l.Ok() {
+		return ifaces.Token{}, l.Error()
+	}
+
+	tok := l.NextToken()
+	switch {
+	case tok == eofToken:
+		if len(t.containers) > 0 {
+			l.SetErr(fmt.Errorf("unterminated JSON container: %w: %w", io.ErrUnexpectedEOF, ErrStdlib))
+
+			return ifaces.Token{}, l.Error()
+		}
+
+		return ifaces.Token{}, io.EOF
+	case tok == invalidToken:
+		return ifaces.Token{}, l.Error()
+	}
+
+	switch tok.Kind() { //nolint:exhaustive // other kinds are not produced with UseNumber
+	case tokenDelim:
+		return t.delim(tok.Delim())
+	case tokenString:
+		value := tok.Token.(string)
+		if t.expectKey {
+			t.expectKey = false
+
+			return ifaces.Token{Kind: ifaces.TokenKey, Text: value}, nil
+		}
+		t.endValue()
+
+		return ifaces.Token{Kind: ifaces.TokenString, Text: value}, nil
+	case tokenNumber:
+		t.endValue()
+
+		return ifaces.Token{Kind: ifaces.TokenNumber, Text: tok.Token.(stdjson.Number).String()}, nil
+	case tokenBool:
+		t.endValue()
+
+		return ifaces.Token{Kind: ifaces.TokenBool, Text: strconv.FormatBool(tok.Token.(bool))}, nil
+	case tokenNull:
+		t.endValue()
+
+		return ifaces.Token{Kind: ifaces.TokenNull, Text: "null"}, nil
+	default:
+		l.SetErr(fmt.Errorf("unexpected JSON token '%v': %w", tok, ErrStdlib))
+
+		return ifaces.Token{}, l.Error()
+	}
+}
+
+// Redeem the lexer to its pool.
+func (t *tokenizer) Redeem() {
+	if t == nil || t.redeem == nil {
+		return
+	}
+
+	t.redeem()
+	t.redeem = nil
+	t.lexer = nil
+	t.containers = nil
+}
+
+func (t *tokenizer) delim(c byte) (ifaces.Token, error) {
+	l := t.lexer
+
+	switch c {
+	case '{', '[':
+		l.depth++
+		if l.maxDepth > 0 && l.depth > l.maxDepth {
+			l.SetErr(fmt.Errorf("maximum nesting depth of %d exceeded: %w", l.maxDepth, ErrStdlib))
+
+			return ifaces.Token{}, l.Error()
+		}
+
+		t.containers = append(t.containers, c)
+		t.expectKey = c == '{'
+
+		if c == '{' {
+			return ifaces.Token{Kind: ifaces.TokenBeginObject, Text: "{"}, nil
+		}
+
+		return ifaces.Token{Kind: ifaces.TokenBeginArray, Text: "["}, nil
+
+	default: // '}' or ']': encoding/json checks that delimiters are balanced
+		l.depth--
+		t.containers = t.containers[:len(t.containers)-1]
+		t.endValue()
+
+		if c == '}' {
+			return ifaces.Token{Kind: ifaces.TokenEndObject, Text: "}"}, nil
+		}
+
+		return ifaces.Token{Kind: ifaces.TokenEndArray, Text: "]"}, nil
+	}
+}
+
+// endValue expects a key next, whenever a value completes inside an object.
+func (t *tokenizer) endValue() {
+	t.expectKey = len(t.containers) > 0 && t.containers[len(t.containers)-1] == '{'
+}
@@ -6,12 +6,16 @@ package json
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"strings"
 )
 
 type jwriter struct {
 	buf *bytes.Buffer
 	err error
+
+	// out is the stream the buffer is flushed to while encoding, if any.
+	out io.Writer
 }
 
 func (w *jwriter) Reset() {
@@ -19,6 +23,7 @@ func (w *jwriter) Reset() {
 		w.buf.Reset()
 	}
 	w.err = nil
+	w.out = nil
 }
 
 // SetErr records the first error encountered while building the JSON output.
@@ -78,6 +83,52 @@ func (w *jwriter) BuildBytes() ([]byte, error) {
 	return bytes.Clone(w.buf.Bytes()), nil
 }
 
+// encode writes the JSON encoding of value, like [json.Marshal] does.
+func (w *jwriter) encode(value any) {
+	w.encodeWith(value, "", "", true)
+}
+
+// encodeWith writes the JSON encoding of value, with the indentation and escaping of a [json.Encoder].
+func (w *jwriter) encodeWith(value any, prefix, indent string, escapeHTML bool) {
+	if w.err != nil {
+		return
+	}
+
+	enc := json.NewEncoder(w.buf)
+	enc.SetEscapeHTML(escapeHTML)
+	enc.SetIndent(prefix, indent)
+
+	if err := enc.Encode(value); err != nil {
+		w.err = err
+
+		return
+	}
+
+	w.buf.Truncate(w.buf.Len() - 1) // the encoder appends a newline
+}
+
+// dumpTo writes the internal buffer to out.
+func (w *jwriter) dumpTo(out io.Writer) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	_, err := w.buf.WriteTo(out)
+
+	return err
+}
+
+// flush writes the internal buffer to the output stream, if any, once it holds at least flushThreshold bytes.
+func (w *jwriter) flush() {
+	if w.out == nil || w.err != nil || w.buf.Len() < flushThreshold {
+		return
+	}
+
+	if _, err := w.buf.WriteTo(w.out); err != nil {
+		w.err = err
+	}
+}
+
 func (w *jwriter) setBuf() {
 	if w.buf != nil {
 		return
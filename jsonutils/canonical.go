@@ -0,0 +1,236 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonutils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+type canonicalError string
+
+func (e canonicalError) Error() string {
+	return string(e)
+}
+
+// ErrCanonical indicates a value that has no canonical JSON representation, e.g. a NaN number.
+const ErrCanonical canonicalError = "cannot produce canonical JSON"
+
+// CanonicalJSON marshals a data structure as canonical JSON, following the JSON Canonicalization Scheme (RFC 8785).
+//
+// Canonical JSON is compact and has:
+//   - keys of objects sorted by their UTF-16 code units
+//   - numbers formatted like ECMAScript does, e.g. 1e+21 or 0.000001
+//   - strings with only the minimal escaping
+//
+// Semantically equal values produce the same bytes, e.g. a [JSONMapSlice], a map[string]any or a struct
+// with the same keys and values, regardless of the order of keys or the registered JSON adapters.
+// This is suitable to sign JSON documents or to use them as keys in a cache.
+//
+// Like in RFC 8785, all numbers are treated as IEEE 754 double precision numbers:
+// integers beyond 2^53 may lose some precision.
+//
+// Values other than dynamic JSON (ordered maps, map[string]any, []any, strings, numbers, booleans and nil)
+// are first marshaled with [WriteJSON].
+func CanonicalJSON(value any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// CanonicalDigest returns the hex-encoded SHA-256 digest of the canonical JSON representation of a data structure.
+//
+// See [CanonicalJSON].
+func CanonicalDigest(value any) (string, error) {
+	canonical, err := CanonicalJSON(value)
+	if err != nil {
+		return "", err
+	}
+
+	digest := sha256.Sum256(canonical)
+
+	return hex.EncodeToString(digest[:]), nil
+}
+
+func writeCanonical(buf *bytes.Buffer, value any) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case string:
+		writeCanonicalString(buf, v)
+	case json.Number, float64, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		number, isNumber := asFloat(v)
+		if !isNumber {
+			return fmt.Errorf("invalid number %q: %w", v, ErrCanonical)
+		}
+
+		return writeCanonicalNumber(buf, number)
+	case []any:
+		buf.WriteByte('[')
+		for i, element := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := writeCanonical(buf, element); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		if isObject(v) {
+			return writeCanonicalObject(buf, v)
+		}
+
+		// other values are marshaled first, then canonicalized as dynamic JSON
+		return writeCanonicalJSON(buf, v)
+	}
+
+	return nil
+}
+
+func writeCanonicalObject(buf *bytes.Buffer, object any) error {
+	type member struct {
+		key   string
+		value any
+	}
+
+	var members []member
+	for key, value := range objectItems(object) {
+		members = append(members, member{key: key, value: value})
+	}
+
+	slices.SortStableFunc(members, func(a, b member) int {
+		return compareUTF16(a.key, b.key)
+	})
+
+	buf.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			if m.key == members[i-1].key {
+				return fmt.Errorf("duplicate key %q: %w", m.key, ErrCanonical)
+			}
+
+			buf.WriteByte(',')
+		}
+
+		writeCanonicalString(buf, m.key)
+		buf.WriteByte(':')
+
+		if err := writeCanonical(buf, m.value); err != nil {
+			return err
+		}
+	}
+	buf.WriteByte('}')
+
+	return nil
+}
+
+func writeCanonicalJSON(buf *bytes.Buffer, value any) error {
+	data, err := WriteJSON(value)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var dynamic any
+	if err := dec.Decode(&dynamic); err != nil {
+		return err
+	}
+
+	return writeCanonical(buf, dynamic)
+}
+
+// writeCanonicalNumber formats a number like the ECMAScript Number.prototype.toString method does.
+func writeCanonicalNumber(buf *bytes.Buffer, number float64) error {
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		return fmt.Errorf("invalid number %v: %w", number, ErrCanonical)
+	}
+
+	if number == 0 { // also for -0
+		buf.WriteByte('0')
+
+		return nil
+	}
+
+	const (
+		minFixed = 1e-6
+		maxFixed = 1e21
+	)
+
+	if abs := math.Abs(number); abs >= minFixed && abs < maxFixed {
+		buf.WriteString(strconv.FormatFloat(number, 'f', -1, 64))
+
+		return nil
+	}
+
+	// ECMAScript doesn't pad the exponent: 1e-7, not 1e-07
+	formatted := strconv.FormatFloat(number, 'e', -1, 64)
+	mantissa, exponent, _ := strings.Cut(formatted, "e")
+	sign, digits := exponent[:1], strings.TrimLeft(exponent[1:], "0")
+
+	buf.WriteString(mantissa)
+	buf.WriteByte('e')
+	buf.WriteString(sign)
+	buf.WriteString(digits)
+
+	return nil
+}
+
+// writeCanonicalString writes a JSON string with only the escaping required by JSON:
+// quotes, backslashes and control characters.
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	const hexDigits = "0123456789abcdef"
+
+	buf.WriteByte('"')
+	for _, r := range s { // invalid UTF-8 is replaced by U+FFFD
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < ' ' {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hexDigits[r>>4])
+				buf.WriteByte(hexDigits[r&0xf])
+
+				continue
+			}
+
+			buf.WriteRune(r)
+		}
+	}
+	buf.WriteByte('"')
+}
+
+// compareUTF16 compares strings by their UTF-16 code units, as required to sort keys in canonical JSON.
+func compareUTF16(a, b string) int {
+	return slices.Compare(utf16.Encode([]rune(a)), utf16.Encode([]rune(b)))
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonutils
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	t.Run("should canonicalize the example of RFC 8785", func(t *testing.T) {
+		const jazon = `{
+  "numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+  "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+  "literals": [null, true, false]
+}`
+		const expected = `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`
+
+		var value any
+		require.NoError(t, json.Unmarshal([]byte(jazon), &value))
+		canonical, err := CanonicalJSON(value)
+		require.NoError(t, err)
+		assert.EqualT(t, expected, string(canonical))
+	})
+
+	t.Run("should sort keys by UTF-16 code units", func(t *testing.T) {
+		canonical, err := CanonicalJSON(map[string]any{
+			"\u20ac":     "Euro Sign",
+			"\r":         "Carriage Return",
+			"\ufb33":     "Hebrew Letter Dalet With Dagesh",
+			"1":          "One",
+			"\U0001f600": "Emoji: Grinning Face",
+			"\u0080":     "Control",
+			"\u00f6":     "Latin Small Letter O With Diaeresis",
+		})
+		require.NoError(t, err)
+		assert.EqualT(t,
+			"{\"\\r\":\"Carriage Return\",\"1\":\"One\",\"\u0080\":\"Control\",\"\u00f6\":\"Latin Small Letter O With Diaeresis\","+
+				"\"\u20ac\":\"Euro Sign\",\"\U0001f600\":\"Emoji: Grinning Face\",\"\ufb33\":\"Hebrew Letter Dalet With Dagesh\"}",
+			string(canonical),
+		)
+	})
+
+	t.Run("should format numbers like ECMAScript", func(t *testing.T) {
+		for bits, expected := range map[uint64]string{
+			0x0000000000000000: "0",
+			0x8000000000000000: "0",
+			0x0000000000000001: "5e-324",
+			0x8000000000000001: "-5e-324",
+			0x7fefffffffffffff: "1.7976931348623157e+308",
+			0xffefffffffffffff: "-1.7976931348623157e+308",
+			0x4340000000000000: "9007199254740992",
+			0xc340000000000000: "-9007199254740992",
+			0x4430000000000000: "295147905179352830000",
+			0x44b52d02c7e14af5: "9.999999999999997e+22",
+			0x44b52d02c7e14af6: "1e+23",
+			0x444b1ae4d6e2ef4e: "999999999999999700000",
+			0x444b1ae4d6e2ef50: "1e+21",
+			0x3eb0c6f7a0b5ed8c: "9.999999999999997e-7",
+			0x3eb0c6f7a0b5ed8d: "0.000001",
+			0x41b3de4355555555: "333333333.3333333",
+			0x41b3de4355555556: "333333333.3333334",
+		} {
+			canonical, err := CanonicalJSON(math.Float64frombits(bits))
+			require.NoError(t, err)
+			assert.EqualT(t, expected, string(canonical))
+		}
+
+		canonical, err := CanonicalJSON([]any{json.Number("1.0"), 2, uint8(3), int64(-4), float32(0.1)})
+		require.NoError(t, err)
+		assert.EqualT(t, `[1,2,3,-4,0.1]`, string(canonical))
+	})
+
+	t.Run("should produce the same JSON for equal values", func(t *testing.T) {
+		type nested struct {
+			Y []int `json:"y"`
+			X bool  `json:"x"`
+		}
+		type document struct {
+			B string  `json:"b"`
+			A float64 `json:"a"`
+			N nested  `json:"n"`
+		}
+
+		values := []any{
+			document{B: "<&>", A: 1.5, N: nested{Y: []int{1, 2}, X: true}},
+			&document{B: "<&>", A: 1.5, N: nested{Y: []int{1, 2}, X: true}},
+			map[string]any{"a": 1.5, "b": "<&>", "n": map[string]any{"x": true, "y": []any{1, 2}}},
+			JSONMapSlice{
+				{Key: "n", Value: JSONMapSlice{{Key: "y", Value: []any{1.0, 2.0}}, {Key: "x", Value: true}}},
+				{Key: "b", Value: "<&>"},
+				{Key: "a", Value: json.Number("15e-1")},
+			},
+			map[string]any{"a": 1.5, "b": "<&>", "n": nested{Y: []int{1, 2}, X: true}},
+		}
+
+		const expected = `{"a":1.5,"b":"<&>","n":{"x":true,"y":[1,2]}}`
+		expectedDigest, err := CanonicalDigest(values[0])
+		require.NoError(t, err)
+		assert.Len(t, expectedDigest, 64)
+
+		for _, value := range values {
+			canonical, err := CanonicalJSON(value)
+			require.NoError(t, err)
+			assert.EqualT(t, expected, string(canonical))
+
+			digest, err := CanonicalDigest(value)
+			require.NoError(t, err)
+			assert.EqualT(t, expectedDigest, digest)
+		}
+
+		digest, err := CanonicalDigest(map[string]any{"a": 1.5})
+		require.NoError(t, err)
+		assert.NotEqual(t, expectedDigest, digest)
+	})
+
+	t.Run("should report values without a canonical JSON", func(t *testing.T) {
+		for _, value := range []any{
+			math.NaN(),
+			math.Inf(-1),
+			[]any{json.Number("x")},
+			JSONMapSlice{{Key: "a", Value: 1}, {Key: "a", Value: 2}},
+		} {
+			_, err := CanonicalJSON(value)
+			require.ErrorIs(t, err, ErrCanonical)
+		}
+
+		_, err := CanonicalJSON(func() {})
+		require.Error(t, err)
+
+		_, err = CanonicalDigest(math.NaN())
+		require.ErrorIs(t, err, ErrCanonical)
+	})
+}
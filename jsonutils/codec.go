@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonutils
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/go-openapi/swag/jsonutils/adapters"
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+)
+
+// globalCodec serves [WriteJSON], [ReadJSON] and [FromDynamicJSON] with the global [adapters.Registry].
+var globalCodec Codec
+
+// Codec marshals and unmarshals JSON like [WriteJSON] and [ReadJSON] do, but with the adapters
+// of its own [adapters.Registrar], or with a single adapter.
+//
+// A [Codec] built with [NewCodec] or [NewCodecWithAdapter] picks the adapter for the value passed to
+// [Codec.Write] or [Codec.Read] without the global [adapters.Registry].
+// This allows several libraries in the same program to use different adapters,
+// or tests to register adapters without interfering with each other.
+//
+// NOTE: this only applies to the top-level value. Ordered maps nested in other values, e.g. a [JSONMapSlice]
+// as the field of a struct, are marshaled and unmarshaled by their own MarshalJSON and UnmarshalJSON methods,
+// which always use the global [adapters.Registry].
+//
+// The zero value of a [Codec] uses the global [adapters.Registry].
+//
+// A [Codec] may be used concurrently.
+type Codec struct {
+	registrar *adapters.Registrar
+	adapter   ifaces.Adapter
+}
+
+// NewCodec builds a [Codec] bound to an [adapters.Registrar].
+//
+// The [adapters.Registrar] holds the cache of the adapters resolved for every type of value.
+// If registrar is nil, the [Codec] uses a new [adapters.Registrar] with the default adapters registered.
+func NewCodec(registrar *adapters.Registrar) *Codec {
+	if registrar == nil {
+		registrar = adapters.NewRegistrar()
+	}
+
+	return &Codec{
+		registrar: registrar,
+	}
+}
+
+// NewCodecWithAdapter builds a [Codec] pinned to a single adapter, which is used for all values.
+//
+// The adapter is never redeemed by the [Codec], so it should not be borrowed from a pool.
+func NewCodecWithAdapter(adapter ifaces.Adapter) *Codec {
+	return &Codec{
+		adapter: adapter,
+	}
+}
+
+// Write marshals a data structure as JSON, like [WriteJSON] does.
+func (c *Codec) Write(value any) ([]byte, error) {
+	if orderedMap, isOrdered := value.(ifaces.Ordered); isOrdered {
+		orderedMarshaler, redeem := c.adapterFor(ifaces.CapabilityOrderedMarshalJSON, orderedMap)
+
+		if orderedMarshaler != nil {
+			defer redeem()
+
+			return orderedMarshaler.OrderedMarshal(orderedMap)
+		}
+
+		// no support found in registered adapters, fallback to the default (unordered) case
+	}
+
+	marshaler, redeem := c.adapterFor(ifaces.CapabilityMarshalJSON, value)
+	if marshaler != nil {
+		defer redeem()
+
+		return marshaler.Marshal(value)
+	}
+
+	// no support found in registered adapters, fallback to the default standard library.
+	//
+	// This only happens when tinkering with the registry of adapters, since the default handles all the above cases.
+	return json.Marshal(value) // Codecov ignore // this is a safeguard not easily simulated in tests
+}
+
+// Read unmarshals JSON data into a data structure, like [ReadJSON] does.
+//
+// NOTE: value must be a pointer.
+func (c *Codec) Read(data []byte, value any) error {
+	trimmedData := bytes.Trim(data, "\x00")
+
+	if orderedMap, isOrdered := value.(ifaces.SetOrdered); isOrdered {
+		// if the value is an ordered map, favors support for OrderedUnmarshal.
+
+		orderedUnmarshaler, redeem := c.adapterFor(ifaces.CapabilityOrderedUnmarshalJSON, orderedMap)
+
+		if orderedUnmarshaler != nil {
+			defer redeem()
+
+			return orderedUnmarshaler.OrderedUnmarshal(trimmedData, orderedMap)
+		}
+
+		// no support found in registered adapters, fallback to the default (unordered) case
+	}
+
+	unmarshaler, redeem := c.adapterFor(ifaces.CapabilityUnmarshalJSON, value)
+	if unmarshaler != nil {
+		defer redeem()
+
+		return unmarshaler.Unmarshal(trimmedData, value)
+	}
+
+	// no support found in registered adapters, fallback to the default standard library.
+	//
+	// This only happens when tinkering with the registry of adapters, since the default handles all the above cases.
+	return json.Unmarshal(trimmedData, value) // Codecov ignore // this is a safeguard not easily simulated in tests
+}
+
+// FromDynamic turns a go value into a properly JSON typed structure, like [FromDynamicJSON] does.
+//
+// NOTE: target must be a pointer.
+func (c *Codec) FromDynamic(source, target any) error {
+	b, err := c.Write(source)
+	if err != nil {
+		return err
+	}
+
+	return c.Read(b, target)
+}
+
+// adapterFor returns the adapter that supports this capability for this type of value,
+// and the function to call to redeem it.
+func (c *Codec) adapterFor(capability ifaces.Capability, value any) (ifaces.Adapter, func()) {
+	if c.adapter != nil {
+		return c.adapter, func() {}
+	}
+
+	registrar := c.registrar
+	if registrar == nil {
+		registrar = adapters.Registry
+	}
+
+	adapter := registrar.AdapterFor(capability, value)
+	if adapter == nil {
+		return nil, nil
+	}
+
+	return adapter, adapter.Redeem
+}
@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonutils
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/go-openapi/swag/jsonutils/adapters"
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+	stdlib "github.com/go-openapi/swag/jsonutils/adapters/stdlib/json"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestCodec(t *testing.T) {
+	type object struct {
+		A int    `json:"a"`
+		B string `json:"b"`
+	}
+
+	obj := object{A: 1, B: "x"}
+	const jazon = `{"z":1,"a":{"y":true,"b":null}}`
+
+	testCodec := func(t *testing.T, codec *Codec) {
+		t.Helper()
+
+		expected, err := json.Marshal(obj)
+		require.NoError(t, err)
+
+		data, err := codec.Write(obj)
+		require.NoError(t, err)
+		assert.EqualT(t, string(expected), string(data))
+
+		var obj1 object
+		require.NoError(t, codec.Read(data, &obj1))
+		assert.EqualT(t, obj, obj1)
+
+		var ordered JSONMapSlice
+		require.NoError(t, codec.Read([]byte(jazon), &ordered))
+		data, err = codec.Write(ordered)
+		require.NoError(t, err)
+		assert.EqualT(t, jazon, string(data))
+
+		var dynamic JSONMapSlice
+		require.NoError(t, codec.FromDynamic(ordered, &dynamic))
+		assert.Equal(t, ordered, dynamic)
+
+		require.Error(t, codec.FromDynamic(func() {}, &dynamic))
+	}
+
+	t.Run("with its own default registrar", func(t *testing.T) {
+		testCodec(t, NewCodec(nil))
+	})
+
+	t.Run("with the global registry", func(t *testing.T) {
+		var codec Codec
+		testCodec(t, &codec)
+	})
+
+	t.Run("should be isolated from the global registry", func(t *testing.T) {
+		recorder := &recordingAdapter{Adapter: stdlib.NewAdapter()}
+		registrar := adapters.NewRegistrar()
+		registrar.RegisterFor(recorder.entry())
+		codec := NewCodec(registrar)
+
+		globalRecorder := &recordingAdapter{Adapter: stdlib.NewAdapter()}
+		adapters.Registry.Reset()
+		adapters.Registry.RegisterFor(globalRecorder.entry())
+		t.Cleanup(adapters.Registry.Reset)
+
+		testCodec(t, codec)
+		assert.Equal(t, []string{"Marshal", "Unmarshal", "OrderedUnmarshal", "OrderedMarshal", "OrderedMarshal", "OrderedUnmarshal", "Marshal"}, recorder.calls)
+		assert.Empty(t, globalRecorder.calls)
+
+		t.Run("the global registry should not use the adapters of the codec", func(t *testing.T) {
+			recorder.calls = nil
+
+			_, err := WriteJSON(obj)
+			require.NoError(t, err)
+			assert.Empty(t, recorder.calls)
+			assert.Equal(t, []string{"Marshal"}, globalRecorder.calls)
+		})
+
+		t.Run("nested ordered maps should use the global registry", func(t *testing.T) {
+			type nested struct {
+				M JSONMapSlice `json:"m"`
+			}
+
+			recorder.calls = nil
+			globalRecorder.calls = nil
+
+			value := nested{M: JSONMapSlice{{Key: "z", Value: int64(1)}, {Key: "a", Value: int64(2)}}}
+			data, err := codec.Write(value)
+			require.NoError(t, err)
+			assert.EqualT(t, `{"m":{"z":1,"a":2}}`, string(data))
+
+			var back nested
+			require.NoError(t, codec.Read(data, &back))
+			assert.Equal(t, value, back)
+
+			assert.Equal(t, []string{"Marshal", "Unmarshal"}, recorder.calls)
+			assert.Equal(t, []string{"OrderedMarshal", "OrderedUnmarshal"}, globalRecorder.calls)
+		})
+
+		t.Run("should not be affected by resetting the global registry", func(t *testing.T) {
+			adapters.Registry.Reset()
+			recorder.calls = nil
+
+			_, err := codec.Write(obj)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"Marshal"}, recorder.calls)
+		})
+	})
+
+	t.Run("with a pinned adapter", func(t *testing.T) {
+		recorder := &recordingAdapter{Adapter: stdlib.NewAdapter()}
+		codec := NewCodecWithAdapter(recorder)
+
+		testCodec(t, codec)
+		assert.Equal(t, []string{"Marshal", "Unmarshal", "OrderedUnmarshal", "OrderedMarshal", "OrderedMarshal", "OrderedUnmarshal", "Marshal"}, recorder.calls)
+	})
+
+	t.Run("should fallback to the standard library without a registered adapter", func(t *testing.T) {
+		registrar := adapters.NewRegistrar()
+		registrar.Reset()
+		registrar.RegisterFor(ifaces.RegistryEntry{
+			Who:         "nothing",
+			What:        ifaces.AllCapabilities,
+			Constructor: stdlib.BorrowAdapterIface,
+			Support:     func(ifaces.Capability, any) bool { return false },
+		})
+
+		testCodec(t, NewCodec(registrar))
+	})
+
+	t.Run("should be used concurrently", func(t *testing.T) {
+		codec := NewCodec(nil)
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Go(func() {
+				var ordered JSONMapSlice
+				assert.NoError(t, codec.Read([]byte(jazon), &ordered))
+
+				data, err := codec.Write(ordered)
+				assert.NoError(t, err)
+				assert.EqualT(t, jazon, string(data))
+			})
+		}
+		wg.Wait()
+	})
+}
+
+// recordingAdapter records the calls to the adapter it wraps.
+//
+// It is not safe for concurrent use.
+type recordingAdapter struct {
+	ifaces.Adapter
+
+	calls []string
+}
+
+func (a *recordingAdapter) entry() ifaces.RegistryEntry {
+	return ifaces.RegistryEntry{
+		Who:         "recordingAdapter",
+		What:        ifaces.AllCapabilities,
+		Constructor: func() ifaces.Adapter { return a },
+		Support:     func(ifaces.Capability, any) bool { return true },
+	}
+}
+
+func (a *recordingAdapter) Marshal(value any) ([]byte, error) {
+	a.calls = append(a.calls, "Marshal")
+
+	return a.Adapter.Marshal(value)
+}
+
+func (a *recordingAdapter) Unmarshal(data []byte, value any) error {
+	a.calls = append(a.calls, "Unmarshal")
+
+	return a.Adapter.Unmarshal(data, value)
+}
+
+func (a *recordingAdapter) OrderedMarshal(value ifaces.Ordered) ([]byte, error) {
+	a.calls = append(a.calls, "OrderedMarshal")
+
+	return a.Adapter.OrderedMarshal(value)
+}
+
+func (a *recordingAdapter) OrderedUnmarshal(data []byte, value ifaces.SetOrdered) error {
+	a.calls = append(a.calls, "OrderedUnmarshal")
+
+	return a.Adapter.OrderedUnmarshal(data, value)
+}
+
+func (a *recordingAdapter) Redeem() {}
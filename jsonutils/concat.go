@@ -24,7 +24,7 @@ func init() {
 // ConcatJSON concatenates multiple json objects or arrays efficiently.
 //
 // Note that [ConcatJSON] performs a very simple (and fast) concatenation
-// operation: it does not attempt to merge objects.
+// operation: it does not attempt to merge objects. Use [MergeJSON] to merge JSON documents.
 func ConcatJSON(blobs ...[]byte) []byte {
 	if len(blobs) == 0 {
 		return nil
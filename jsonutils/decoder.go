@@ -0,0 +1,211 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonutils
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/swag/jsonutils/adapters"
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+	stdlib "github.com/go-openapi/swag/jsonutils/adapters/stdlib/json"
+)
+
+type (
+	// Token is a JSON token returned by [Decoder.Next].
+	Token = ifaces.Token
+
+	// TokenKind indicates the kind of a [Token].
+	TokenKind = ifaces.TokenKind
+)
+
+const (
+	TokenBeginObject = ifaces.TokenBeginObject
+	TokenEndObject   = ifaces.TokenEndObject
+	TokenBeginArray  = ifaces.TokenBeginArray
+	TokenEndArray    = ifaces.TokenEndArray
+	TokenKey         = ifaces.TokenKey
+	TokenString      = ifaces.TokenString
+	TokenNumber      = ifaces.TokenNumber
+	TokenBool        = ifaces.TokenBool
+	TokenNull        = ifaces.TokenNull
+)
+
+var errDecoderClosed = errors.New("JSON decoder is closed")
+
+// Decoder reads a stream of JSON tokens, without unmarshaling the document.
+//
+// This is useful to scan large documents, e.g. to collect all "$ref" values.
+//
+// The tokens are read by the first registered adapter that supports the [ifaces.CapabilityTokenizeJSON]
+// capability for this reader, like [ReadJSON] does for unmarshaling.
+// The nesting depth of documents is limited, like when unmarshaling ordered JSON.
+//
+// The resources borrowed by the [Decoder] are released once the stream is consumed or an error occurs,
+// or when calling [Decoder.Close].
+type Decoder struct {
+	tokenizer ifaces.Tokenizer
+	frames    []frame
+	last      TokenKind
+	err       error
+}
+
+// frame tracks the position of the decoder in a JSON container.
+type frame struct {
+	object bool
+	key    string
+	index  int // -1 until the first element of the container is read
+}
+
+// NewDecoder yields a [Decoder] that reads JSON tokens from r.
+func NewDecoder(r io.Reader) *Decoder {
+	adapter := adapters.TokenizerAdapterFor(r)
+	if adapter == nil {
+		// no support found in registered adapters, fallback to the default standard library.
+		return &Decoder{
+			tokenizer: stdlib.NewAdapter().NewTokenizer(r),
+		}
+	}
+	defer adapter.Redeem()
+
+	return &Decoder{
+		tokenizer: adapter.NewTokenizer(r),
+	}
+}
+
+// Next returns the next JSON token in the stream.
+//
+// Object keys are returned as [TokenKey], distinct from string values returned as [TokenString].
+// Commas and colons are elided.
+//
+// The stream may hold several JSON values, one after another. At the end of the stream, Next returns [io.EOF].
+func (d *Decoder) Next() (Token, error) {
+	if d.err != nil {
+		return Token{}, d.err
+	}
+
+	token, err := d.tokenizer.NextToken()
+	if err != nil {
+		d.err = err
+		d.release()
+
+		return Token{}, err
+	}
+
+	d.track(token)
+
+	return token, nil
+}
+
+// Skip skips the value that follows the last token returned by [Decoder.Next].
+//
+//   - after a [TokenKey], the value of this key is skipped
+//   - after a [TokenBeginObject] or [TokenBeginArray], the remainder of this container is skipped,
+//     up to and including its closing token
+//
+// Otherwise, Skip does nothing.
+func (d *Decoder) Skip() error {
+	switch d.last { //nolint:exhaustive // other tokens have nothing to skip
+	case TokenKey:
+		token, err := d.Next()
+		if err != nil {
+			return err
+		}
+
+		if token.Kind != TokenBeginObject && token.Kind != TokenBeginArray {
+			return nil
+		}
+	case TokenBeginObject, TokenBeginArray:
+	default:
+		return nil
+	}
+
+	for depth := 1; depth > 0; {
+		token, err := d.Next()
+		if err != nil {
+			return err
+		}
+
+		switch token.Kind { //nolint:exhaustive // only containers matter here
+		case TokenBeginObject, TokenBeginArray:
+			depth++
+		case TokenEndObject, TokenEndArray:
+			depth--
+		}
+	}
+
+	return nil
+}
+
+// Path returns the location of the last token returned by [Decoder.Next], as a JSON pointer (RFC 6901).
+//
+// The location of a [TokenKey] is the location of its value. The location of the
+// tokens that begin or end a container is the location of this container.
+//
+// Path returns the empty string for a top-level value.
+func (d *Decoder) Path() string {
+	var b strings.Builder
+
+	for _, f := range d.frames {
+		if f.index < 0 {
+			continue
+		}
+
+		b.WriteByte('/')
+		if f.object {
+			b.WriteString(escapePointerToken(f.key))
+
+			continue
+		}
+
+		b.WriteString(strconv.Itoa(f.index))
+	}
+
+	return b.String()
+}
+
+// Close releases the resources held by the [Decoder].
+//
+// Close is not needed when the stream has been read until [io.EOF] or an error.
+// The [Decoder] must not be used after Close.
+func (d *Decoder) Close() error {
+	if d.err == nil {
+		d.err = errDecoderClosed
+	}
+	d.release()
+
+	return nil
+}
+
+func (d *Decoder) release() {
+	if d.tokenizer == nil {
+		return
+	}
+
+	d.tokenizer.Redeem()
+	d.tokenizer = nil
+}
+
+func (d *Decoder) track(token Token) {
+	d.last = token.Kind
+
+	switch token.Kind { //nolint:exhaustive // other tokens are values
+	case TokenKey:
+		top := &d.frames[len(d.frames)-1]
+		top.key = token.Text
+		top.index++
+	case TokenEndObject, TokenEndArray:
+		d.frames = d.frames[:len(d.frames)-1]
+	default:
+		if n := len(d.frames); n > 0 && !d.frames[n-1].object {
+			d.frames[n-1].index++
+		}
+
+		if token.Kind == TokenBeginObject || token.Kind == TokenBeginArray {
+			d.frames = append(d.frames, frame{object: token.Kind == TokenBeginObject, index: -1})
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonutils
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/swag/jsonutils/adapters"
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestDecoder(t *testing.T) {
+	const jazon = `{"a":{"$ref":"#/x"},"b/c":[1,{"$ref":"#/y"},[true]],"d~":null}`
+
+	t.Run("should report the JSON pointer of every token", func(t *testing.T) {
+		d := NewDecoder(strings.NewReader(jazon))
+
+		var paths []string
+		for {
+			token, err := d.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+
+			paths = append(paths, token.String()+" "+d.Path())
+		}
+
+		assert.Equal(t, []string{
+			"BeginObject({) ",
+			"Key(a) /a",
+			"BeginObject({) /a",
+			"Key($ref) /a/$ref",
+			"String(#/x) /a/$ref",
+			"EndObject(}) /a",
+			"Key(b/c) /b~1c",
+			"BeginArray([) /b~1c",
+			"Number(1) /b~1c/0",
+			"BeginObject({) /b~1c/1",
+			"Key($ref) /b~1c/1/$ref",
+			"String(#/y) /b~1c/1/$ref",
+			"EndObject(}) /b~1c/1",
+			"BeginArray([) /b~1c/2",
+			"Bool(true) /b~1c/2/0",
+			"EndArray(]) /b~1c/2",
+			"EndArray(]) /b~1c",
+			"Key(d~) /d~0",
+			"Null(null) /d~0",
+			"EndObject(}) ",
+		}, paths)
+
+		t.Run("should keep returning EOF at the end of the stream", func(t *testing.T) {
+			_, err := d.Next()
+			require.ErrorIs(t, err, io.EOF)
+		})
+	})
+
+	t.Run("should collect $ref values", func(t *testing.T) {
+		d := NewDecoder(strings.NewReader(jazon))
+		defer func() { _ = d.Close() }()
+
+		refs := make(map[string]string)
+		for {
+			token, err := d.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+
+			if token.Kind != TokenKey || token.Text != "$ref" {
+				continue
+			}
+
+			value, err := d.Next()
+			require.NoError(t, err)
+			refs[d.Path()] = value.Text
+		}
+
+		assert.Equal(t, map[string]string{
+			"/a/$ref":      "#/x",
+			"/b~1c/1/$ref": "#/y",
+		}, refs)
+	})
+
+	t.Run("should skip the value of a key", func(t *testing.T) {
+		d := NewDecoder(strings.NewReader(jazon))
+
+		_, err := d.Next() // {
+		require.NoError(t, err)
+		token, err := d.Next() // "a"
+		require.NoError(t, err)
+		require.EqualT(t, TokenKey, token.Kind)
+
+		require.NoError(t, d.Skip())
+
+		token, err = d.Next()
+		require.NoError(t, err)
+		assert.EqualT(t, "Key(b/c)", token.String())
+		assert.EqualT(t, "/b~1c", d.Path())
+
+		require.NoError(t, d.Skip())
+
+		token, err = d.Next()
+		require.NoError(t, err)
+		assert.EqualT(t, "Key(d~)", token.String())
+
+		require.NoError(t, d.Skip()) // scalar value
+
+		token, err = d.Next()
+		require.NoError(t, err)
+		assert.EqualT(t, TokenEndObject, token.Kind)
+	})
+
+	t.Run("should skip the remainder of a container", func(t *testing.T) {
+		d := NewDecoder(strings.NewReader(`[[1,[2]],3]`))
+
+		_, err := d.Next() // [
+		require.NoError(t, err)
+		_, err = d.Next() // [
+		require.NoError(t, err)
+
+		require.NoError(t, d.Skip())
+		assert.EqualT(t, "/0", d.Path())
+
+		require.NoError(t, d.Skip()) // no-op after the end of a container
+
+		token, err := d.Next()
+		require.NoError(t, err)
+		assert.EqualT(t, "Number(3)", token.String())
+		assert.EqualT(t, "/1", d.Path())
+	})
+
+	t.Run("should report errors when skipping a truncated document", func(t *testing.T) {
+		d := NewDecoder(strings.NewReader(`{"a":[1,2`))
+
+		_, err := d.Next()
+		require.NoError(t, err)
+		require.Error(t, d.Skip())
+	})
+
+	t.Run("should report errors on invalid JSON", func(t *testing.T) {
+		d := NewDecoder(strings.NewReader(`{"a":}`))
+
+		var err error
+		for err == nil {
+			_, err = d.Next()
+		}
+		require.Error(t, err)
+		require.FalseT(t, errors.Is(err, io.EOF))
+
+		_, again := d.Next()
+		require.ErrorIs(t, again, err)
+	})
+
+	t.Run("should enforce the max nesting depth", func(t *testing.T) {
+		const depth = 10001
+		d := NewDecoder(strings.NewReader(strings.Repeat("[", depth) + strings.Repeat("]", depth)))
+
+		var err error
+		for err == nil {
+			_, err = d.Next()
+		}
+		require.Error(t, err)
+		require.FalseT(t, errors.Is(err, io.EOF))
+	})
+
+	t.Run("should not be usable after Close", func(t *testing.T) {
+		d := NewDecoder(strings.NewReader(jazon))
+		require.NoError(t, d.Close())
+		require.NoError(t, d.Close())
+
+		_, err := d.Next()
+		require.Error(t, err)
+	})
+
+	t.Run("should fallback to the standard library without a registered tokenizer", func(t *testing.T) {
+		adapters.Registry.Reset()
+		adapters.Registry.RegisterFor(withoutStreamsEntry(ifaces.AllStreamingCapabilities))
+		t.Cleanup(adapters.Registry.Reset)
+
+		d := NewDecoder(strings.NewReader(`[1]`))
+		token, err := d.Next()
+		require.NoError(t, err)
+		assert.EqualT(t, TokenBeginArray, token.Kind)
+		require.NoError(t, d.Close())
+	})
+}
+
+// adapterWithoutStreams is an adapter that claims to support some streaming capabilities, but does not implement them.
+type adapterWithoutStreams struct {
+	ifaces.Adapter
+}
+
+func (adapterWithoutStreams) Redeem() {}
+
+func withoutStreamsEntry(what ifaces.Capabilities) ifaces.RegistryEntry {
+	return ifaces.RegistryEntry{
+		Who:         "adapterWithoutStreams",
+		What:        what,
+		Constructor: func() ifaces.Adapter { return adapterWithoutStreams{} },
+		Support:     func(ifaces.Capability, any) bool { return true },
+	}
+}
@@ -4,4 +4,20 @@
 // Package jsonutils provides helpers to work with JSON.
 //
 // These utilities work with dynamic go structures to and from JSON.
+//
+// A [Codec] marshals and unmarshals JSON like [WriteJSON] and [ReadJSON], with adapters
+// picked without the global registry for the top-level value.
+//
+// [WriteJSONWith] writes JSON with some control over its layout, e.g. indented.
+//
+// [MergeJSON] merges JSON documents with the semantics of JSON Merge Patch (RFC 7396).
+//
+// [ApplyPatch] and [Diff] apply and build JSON patches (RFC 6902) on dynamic JSON documents,
+// with the order of keys maintained in ordered maps such as [JSONMapSlice].
+//
+// [Get], [Set], [Delete] and [Walk] navigate and update such documents with JSON pointers (RFC 6901).
+//
+// [CanonicalJSON] produces canonical JSON (RFC 8785), e.g. to sign documents, and [CanonicalDigest] a digest of it.
+//
+// A [Decoder] reads a stream of JSON tokens, e.g. to scan large documents without unmarshaling them.
 package jsonutils
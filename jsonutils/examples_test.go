@@ -4,7 +4,10 @@
 package jsonutils_test
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/go-openapi/swag/jsonutils"
 )
@@ -129,3 +132,60 @@ func ExampleJSONMapSlice() {
 	// {"a":1,"c":"x","b":2}
 	// jsonutils.JSONMapSlice{jsonutils.JSONMapItem{Key:"a", Value:1}, jsonutils.JSONMapItem{Key:"c", Value:"x"}, jsonutils.JSONMapItem{Key:"b", Value:2}}
 }
+
+func ExampleDecoder() {
+	const jazon = `{"a": {"$ref": "#/definitions/x"}, "b": [{"$ref": "#/definitions/y"}]}`
+
+	d := jsonutils.NewDecoder(strings.NewReader(jazon))
+	defer func() { _ = d.Close() }()
+
+	for {
+		token, err := d.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			panic(err)
+		}
+
+		if token.Kind != jsonutils.TokenKey || token.Text != "$ref" {
+			continue
+		}
+
+		ref, err := d.Next()
+		if err != nil {
+			panic(err)
+		}
+
+		fmt.Println(d.Path(), ref.Text)
+	}
+
+	// Output:
+	// /a/$ref #/definitions/x
+	// /b/0/$ref #/definitions/y
+}
+
+func ExampleMergeJSON() {
+	const (
+		fragment1 = `{"info":{"title":"API","version":"1.0"},"tags":["a"]}`
+		fragment2 = `{"info":{"version":"1.1","x-draft":null},"tags":["b"]}`
+	)
+
+	merged, err := jsonutils.MergeJSON([]byte(fragment1), []byte(fragment2),
+		jsonutils.WithMergeArrays(jsonutils.MergeArraysAppend),
+		jsonutils.WithMergeConflicts(func(c jsonutils.MergeConflict) error {
+			fmt.Printf("conflict at %s: %v replaced by %v\n", c.Path, c.Target, c.Patch)
+
+			return nil
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(string(merged))
+
+	// Output:
+	// conflict at /info/version: 1.0 replaced by 1.1
+	// {"info":{"title":"API","version":"1.1"},"tags":["a","b"]}
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonutils
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/go-openapi/swag/jsonutils/adapters"
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+)
+
+// WriteOption selects options for [WriteJSONWith].
+type WriteOption func(*ifaces.FormatOptions)
+
+// WithIndent indents the JSON output, with one copy of indent for every nesting level, like [json.MarshalIndent].
+func WithIndent(indent string) WriteOption {
+	return func(o *ifaces.FormatOptions) {
+		o.Indent = indent
+	}
+}
+
+// WithPrefix starts every line of indented JSON with a prefix, except the first one, like [json.MarshalIndent].
+//
+// The JSON output is indented whenever a prefix is set, even without [WithIndent].
+func WithPrefix(prefix string) WriteOption {
+	return func(o *ifaces.FormatOptions) {
+		o.Prefix = prefix
+	}
+}
+
+// WithEscapeHTML escapes the characters <, > and & in JSON strings, like [json.Marshal] does.
+//
+// This is enabled by default.
+func WithEscapeHTML(enabled bool) WriteOption {
+	return func(o *ifaces.FormatOptions) {
+		o.EscapeHTML = enabled
+	}
+}
+
+// WithTrailingNewline terminates the JSON output with a newline, like [json.Encoder] does.
+func WithTrailingNewline(enabled bool) WriteOption {
+	return func(o *ifaces.FormatOptions) {
+		o.TrailingNewline = enabled
+	}
+}
+
+// WriteJSONWith marshals a data structure as JSON, like [WriteJSON] does, with options to control the layout
+// of the output, e.g. to indent it.
+//
+// Registered adapters that support the [ifaces.CapabilityFormatJSON] capability produce the formatted output
+// in a single pass, with the order of keys maintained for ordered maps.
+// Otherwise, the output of [WriteJSON] is formatted afterwards.
+func WriteJSONWith(value any, opts ...WriteOption) ([]byte, error) {
+	format := ifaces.FormatOptions{EscapeHTML: true}
+	for _, apply := range opts {
+		apply(&format)
+	}
+
+	formatter := adapters.FormatMarshalAdapterFor(value)
+	if formatter != nil {
+		defer formatter.Redeem()
+
+		return formatter.FormatMarshal(value, format)
+	}
+
+	// no support found in registered adapters: format the output of the marshaling adapter.
+	data, err := WriteJSON(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return formatJSON(data, format)
+}
+
+// formatJSON lays out some JSON bytes like a [ifaces.FormatMarshalAdapter] does.
+func formatJSON(data []byte, format ifaces.FormatOptions) ([]byte, error) {
+	if format.IsIndented() {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, format.Prefix, format.Indent); err != nil {
+			return nil, err
+		}
+
+		data = buf.Bytes()
+	}
+
+	if format.EscapeHTML {
+		var buf bytes.Buffer
+		json.HTMLEscape(&buf, data)
+		data = buf.Bytes()
+	} else {
+		data = unescapeHTML(data)
+	}
+
+	if format.TrailingNewline {
+		data = append(data, '\n')
+	}
+
+	return data, nil
+}
+
+var htmlUnescaper = map[string]byte{
+	`\u003c`: '<',
+	`\u003e`: '>',
+	`\u0026`: '&',
+}
+
+// unescapeHTML reverts the escaping of the characters <, > and & in JSON strings, as done by [json.HTMLEscape].
+func unescapeHTML(data []byte) []byte {
+	const escapeLen = len(`\u003c`)
+
+	if !bytes.Contains(data, []byte(`\u00`)) {
+		return data
+	}
+
+	unescaped := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if c != '\\' || i+1 == len(data) {
+			unescaped = append(unescaped, c)
+
+			continue
+		}
+
+		if i+escapeLen <= len(data) {
+			if char, isHTML := htmlUnescaper[string(data[i:i+escapeLen])]; isHTML {
+				unescaped = append(unescaped, char)
+				i += escapeLen - 1
+
+				continue
+			}
+		}
+
+		// other escape sequences are kept, e.g. \\u003c is an escaped backslash
+		unescaped = append(unescaped, c, data[i+1])
+		i++
+	}
+
+	return unescaped
+}
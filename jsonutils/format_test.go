@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonutils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/swag/jsonutils/adapters"
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestWriteJSONWith(t *testing.T) {
+	ordered := JSONMapSlice{
+		{Key: "z", Value: JSONMapSlice{{Key: "y", Value: []any{1, "<&>"}}}},
+		{Key: "a", Value: JSONMapSlice{}},
+	}
+	obj := AggregationObject{Count: 290, SharedCounters: SharedCounters{Counter1: 304, Counter2: 948}}
+
+	const (
+		indented  = "{\n  \"z\": {\n    \"y\": [\n      1,\n      \"\\u003c\\u0026\\u003e\"\n    ]\n  },\n  \"a\": {}\n}"
+		prefixed  = "{\n>\t\"z\": {\n>\t\t\"y\": [\n>\t\t\t1,\n>\t\t\t\"<&>\"\n>\t\t]\n>\t},\n>\t\"a\": {}\n>}\n"
+		unescaped = `{"z":{"y":[1,"<&>"]},"a":{}}`
+	)
+
+	testWriteJSONWith := func(t *testing.T) {
+		t.Run("should write the same JSON as WriteJSON by default", func(t *testing.T) {
+			for _, value := range []any{ordered, obj, map[string]any{"a": "<&>"}} {
+				expected, err := WriteJSON(value)
+				require.NoError(t, err)
+
+				formatted, err := WriteJSONWith(value)
+				require.NoError(t, err)
+				assert.EqualT(t, string(expected), string(formatted))
+			}
+		})
+
+		t.Run("should indent JSON with the order of keys maintained", func(t *testing.T) {
+			formatted, err := WriteJSONWith(ordered, WithIndent("  "))
+			require.NoError(t, err)
+			assert.EqualT(t, indented, string(formatted))
+
+			formatted, err = WriteJSONWith(ordered,
+				WithPrefix(">"), WithIndent("\t"), WithEscapeHTML(false), WithTrailingNewline(true),
+			)
+			require.NoError(t, err)
+			assert.EqualT(t, prefixed, string(formatted))
+		})
+
+		t.Run("should write compact JSON without escaping HTML", func(t *testing.T) {
+			formatted, err := WriteJSONWith(ordered, WithEscapeHTML(false))
+			require.NoError(t, err)
+			assert.EqualT(t, unescaped, string(formatted))
+		})
+
+		t.Run("should indent JSON like json.MarshalIndent", func(t *testing.T) {
+			expected, err := json.MarshalIndent(obj, "", "  ")
+			require.NoError(t, err)
+
+			formatted, err := WriteJSONWith(obj, WithIndent("  "))
+			require.NoError(t, err)
+			assert.EqualT(t, string(expected), string(formatted))
+		})
+
+		t.Run("should report errors", func(t *testing.T) {
+			_, err := WriteJSONWith(func() {}, WithIndent("  "))
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("with the default adapter", testWriteJSONWith)
+
+	t.Run("should fallback to the byte-based adapters without a registered format adapter", func(t *testing.T) {
+		adapters.Registry.Reset()
+		adapters.Registry.RegisterFor(withoutStreamsEntry(ifaces.Capabilities(ifaces.CapabilityFormatJSON)))
+		t.Cleanup(adapters.Registry.Reset)
+
+		testWriteJSONWith(t)
+	})
+}
+
+func TestUnescapeHTML(t *testing.T) {
+	for input, expected := range map[string]string{
+		``:                     ``,
+		`"a"`:                  `"a"`,
+		`"\u003c\u0026\u003e"`: `"<&>"`,
+		`"\\u003c"`:            `"\\u003c"`,
+		`"\\\u003e"`:           `"\\>"`,
+		`"\u00e9\u003"`:        `"\u00e9\u003"`,
+		`"\n\u0026"`:           `"\n&"`,
+	} {
+		assert.EqualT(t, expected, string(unescapeHTML([]byte(input))))
+	}
+}
@@ -4,11 +4,9 @@
 package jsonutils
 
 import (
-	"bytes"
-	"encoding/json"
+	"io"
 
 	"github.com/go-openapi/swag/jsonutils/adapters"
-	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
 )
 
 // WriteJSON marshals a data structure as JSON.
@@ -21,30 +19,10 @@ import (
 //
 // NOTE: to allow types that are [easyjson.Marshaler] s to use that route to process JSON,
 // you now need to register the adapter for easyjson at runtime.
+//
+// To use adapters independently of the global [adapters.Registry], see [Codec].
 func WriteJSON(value any) ([]byte, error) {
-	if orderedMap, isOrdered := value.(ifaces.Ordered); isOrdered {
-		orderedMarshaler := adapters.OrderedMarshalAdapterFor(orderedMap)
-
-		if orderedMarshaler != nil {
-			defer orderedMarshaler.Redeem()
-
-			return orderedMarshaler.OrderedMarshal(orderedMap)
-		}
-
-		// no support found in registered adapters, fallback to the default (unordered) case
-	}
-
-	marshaler := adapters.MarshalAdapterFor(value)
-	if marshaler != nil {
-		defer marshaler.Redeem()
-
-		return marshaler.Marshal(value)
-	}
-
-	// no support found in registered adapters, fallback to the default standard library.
-	//
-	// This only happens when tinkering with the global registry of adapters, since the default handles all the above cases.
-	return json.Marshal(value) // Codecov ignore // this is a safeguard not easily simulated in tests
+	return globalCodec.Write(value)
 }
 
 // ReadJSON unmarshals JSON data into a data structure.
@@ -63,34 +41,68 @@ func WriteJSON(value any) ([]byte, error) {
 //
 // NOTE: to allow types that are [easyjson.Unmarshaler] s to use that route to process JSON,
 // you now need to register the adapter for easyjson at runtime.
+//
+// To use adapters independently of the global [adapters.Registry], see [Codec].
 func ReadJSON(data []byte, value any) error {
-	trimmedData := bytes.Trim(data, "\x00")
+	return globalCodec.Read(data, value)
+}
 
-	if orderedMap, isOrdered := value.(ifaces.SetOrdered); isOrdered {
-		// if the value is an ordered map, favors support for OrderedUnmarshal.
+// WriteJSONTo writes the JSON encoding of a data structure to a stream.
+//
+// It writes the same JSON as [WriteJSON], using some registered adapter that supports
+// the [ifaces.CapabilityEncodeJSON] capability for this value. Otherwise, it falls back to [WriteJSON].
+//
+// The default adapters write ordered maps such as [JSONMapSlice] to w in chunks, without building
+// the whole output in memory. Other values are built in memory, then written to w.
+//
+// When an error is returned, part of the output may already have been written to w.
+//
+// See [adapters.Registrar] for more details about how to configure
+// multiple serialization alternatives.
+func WriteJSONTo(w io.Writer, value any) error {
+	encoder := adapters.EncodeAdapterFor(value)
+	if encoder != nil {
+		defer encoder.Redeem()
 
-		orderedUnmarshaler := adapters.OrderedUnmarshalAdapterFor(orderedMap)
+		return encoder.Encode(w, value)
+	}
 
-		if orderedUnmarshaler != nil {
-			defer orderedUnmarshaler.Redeem()
+	// no support found in registered adapters, fallback to the byte-based adapters.
+	data, err := WriteJSON(value)
+	if err != nil {
+		return err
+	}
 
-			return orderedUnmarshaler.OrderedUnmarshal(trimmedData, orderedMap)
-		}
+	_, err = w.Write(data)
 
-		// no support found in registered adapters, fallback to the default (unordered) case
-	}
+	return err
+}
+
+// ReadJSONFrom reads a JSON value from a stream into a data structure.
+//
+// It behaves like [ReadJSON], using some registered adapter that supports
+// the [ifaces.CapabilityDecodeJSON] capability for this value: the default adapters read the next JSON value
+// from r, and not r until [io.EOF]. Otherwise, it reads r until [io.EOF] and falls back to [ReadJSON].
+//
+// See [adapters.Registrar] for more details about how to configure
+// multiple serialization alternatives.
+//
+// NOTE: value must be a pointer.
+func ReadJSONFrom(r io.Reader, value any) error {
+	decoder := adapters.DecodeAdapterFor(value)
+	if decoder != nil {
+		defer decoder.Redeem()
 
-	unmarshaler := adapters.UnmarshalAdapterFor(value)
-	if unmarshaler != nil {
-		defer unmarshaler.Redeem()
+		return decoder.Decode(r, value)
+	}
 
-		return unmarshaler.Unmarshal(trimmedData, value)
+	// no support found in registered adapters, fallback to the byte-based adapters.
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
 	}
 
-	// no support found in registered adapters, fallback to the default standard library.
-	//
-	// This only happens when tinkering with the global registry of adapters, since the default handles all the above cases.
-	return json.Unmarshal(trimmedData, value) // Codecov ignore // this is a safeguard not easily simulated in tests
+	return ReadJSON(data, value)
 }
 
 // FromDynamicJSON turns a go value into a properly JSON typed structure.
@@ -107,10 +119,5 @@ func ReadJSON(data []byte, value any) error {
 // they are considered "ordered maps" and the order of keys is maintained in the
 // "jsonification" process. In that case, map[string]any values are replaced by (ordered) [JSONMapSlice] ones.
 func FromDynamicJSON(source, target any) error {
-	b, err := WriteJSON(source)
-	if err != nil {
-		return err
-	}
-
-	return ReadJSON(b, target)
+	return globalCodec.FromDynamic(source, target)
 }
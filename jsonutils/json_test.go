@@ -4,9 +4,14 @@
 package jsonutils
 
 import (
+	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/go-openapi/swag/jsonutils/adapters"
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+
 	"github.com/go-openapi/testify/v2/assert"
 	"github.com/go-openapi/testify/v2/require"
 )
@@ -183,3 +188,57 @@ func TestReadWriteJSON(t *testing.T) {
 		})
 	})
 }
+
+func TestReadWriteJSONStream(t *testing.T) {
+	obj := AggregationObject{Count: 290, SharedCounters: SharedCounters{Counter1: 304, Counter2: 948}}
+
+	t.Run("should write the same JSON as WriteJSON", func(t *testing.T) {
+		expected, err := WriteJSON(obj)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, WriteJSONTo(&buf, obj))
+		assert.EqualT(t, string(expected), buf.String())
+
+		t.Run("should read it back", func(t *testing.T) {
+			var obj1 AggregationObject
+			require.NoError(t, ReadJSONFrom(&buf, &obj1))
+			assert.EqualT(t, obj, obj1)
+		})
+	})
+
+	t.Run("should maintain the order of keys", func(t *testing.T) {
+		const jazon = `{"z":1,"a":{"y":true,"b":null}}`
+
+		var value JSONMapSlice
+		require.NoError(t, ReadJSONFrom(strings.NewReader(jazon), &value))
+
+		var buf bytes.Buffer
+		require.NoError(t, WriteJSONTo(&buf, value))
+		assert.EqualT(t, jazon, buf.String())
+	})
+
+	t.Run("should report errors", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.Error(t, WriteJSONTo(&buf, func() {}))
+
+		var value any
+		require.Error(t, ReadJSONFrom(strings.NewReader(`{`), &value))
+	})
+
+	t.Run("should fallback to the byte-based adapters without a registered stream adapter", func(t *testing.T) {
+		adapters.Registry.Reset()
+		adapters.Registry.RegisterFor(withoutStreamsEntry(ifaces.AllStreamingCapabilities))
+		t.Cleanup(adapters.Registry.Reset)
+
+		var buf bytes.Buffer
+		require.NoError(t, WriteJSONTo(&buf, obj))
+
+		var obj1 AggregationObject
+		require.NoError(t, ReadJSONFrom(&buf, &obj1))
+		assert.EqualT(t, obj, obj1)
+
+		require.Error(t, WriteJSONTo(&buf, func() {}))
+		require.Error(t, ReadJSONFrom(strings.NewReader(`{`), &obj1))
+	})
+}
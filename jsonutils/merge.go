@@ -0,0 +1,302 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonutils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+)
+
+// ArrayMergeStrategy tells how [MergeJSON] merges an array in a patch with an array in the target.
+type ArrayMergeStrategy uint8
+
+const (
+	// MergeArraysReplace replaces the array in the target by the array in the patch, as specified by RFC 7396.
+	MergeArraysReplace ArrayMergeStrategy = iota
+
+	// MergeArraysAppend appends the elements of the array in the patch to the array in the target.
+	MergeArraysAppend
+
+	// MergeArraysByIndex merges the elements of both arrays with the same index.
+	//
+	// Elements in the patch beyond the length of the target are appended.
+	MergeArraysByIndex
+)
+
+// MergeConflict describes a value in the target that a patch replaces with a different value.
+type MergeConflict struct {
+	// Path is the location of the value, as a JSON pointer (RFC 6901).
+	Path string
+
+	// Target is the value in the target.
+	Target any
+
+	// Patch is the value in the patch that replaces it.
+	Patch any
+}
+
+// MergeOption selects options for [MergeJSON] and [Merge].
+type MergeOption func(*mergeOptions)
+
+type mergeOptions struct {
+	arrays    ArrayMergeStrategy
+	conflicts func(MergeConflict) error
+}
+
+// WithMergeArrays selects how arrays are merged.
+//
+// The default is [MergeArraysReplace].
+func WithMergeArrays(strategy ArrayMergeStrategy) MergeOption {
+	return func(o *mergeOptions) {
+		o.arrays = strategy
+	}
+}
+
+// WithMergeConflicts reports the values in the target that are replaced with a different value.
+//
+// Removing a key with a null value in the patch is not a conflict.
+//
+// The merge stops whenever the callback returns an error, and this error is returned.
+func WithMergeConflicts(callback func(MergeConflict) error) MergeOption {
+	return func(o *mergeOptions) {
+		o.conflicts = callback
+	}
+}
+
+// MergeJSON merges a JSON patch into a JSON target document, with the semantics of
+// JSON Merge Patch (RFC 7396), and returns the merged JSON document.
+//
+// Unlike [ConcatJSON], objects are merged recursively: keys present in both documents
+// are merged, and keys with a null value in the patch are removed from the target.
+//
+// The order of keys is maintained: keys from the target come first, then new keys from the patch.
+//
+// By default, arrays in the patch replace arrays in the target. See [WithMergeArrays] to select another strategy.
+//
+// To merge several fragments, merge each fragment in turn into the result of the previous merge.
+func MergeJSON(target, patch []byte, opts ...MergeOption) ([]byte, error) {
+	t, err := readOrderedJSON(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON merge target: %w", err)
+	}
+
+	p, err := readOrderedJSON(patch)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON merge patch: %w", err)
+	}
+
+	merged, err := Merge(t, p, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return WriteJSON(merged)
+}
+
+// Merge merges a patch into a target, with the semantics of JSON Merge Patch (RFC 7396),
+// like [MergeJSON] does, but with dynamic JSON values.
+//
+// Objects are represented either by map[string]any or by ordered maps implementing [ifaces.Ordered],
+// such as [JSONMapSlice]. Arrays are represented by []any.
+//
+// Merged objects are [JSONMapSlice] ordered maps, with the order of keys maintained,
+// unless the target is a map[string]any (or is not an object, and the patch is a map[string]any).
+//
+// The inputs are not modified, but the result may share some values with them.
+func Merge(target, patch any, opts ...MergeOption) (any, error) {
+	var m merger
+	for _, apply := range opts {
+		apply(&m.options)
+	}
+
+	return m.merge(target, true, patch, "")
+}
+
+type merger struct {
+	options mergeOptions
+}
+
+func (m *merger) merge(target any, exists bool, patch any, path string) (any, error) {
+	if isObject(patch) {
+		return m.mergeObjects(target, patch, path)
+	}
+
+	if patchArray, isArray := patch.([]any); isArray {
+		if targetArray, isTargetArray := target.([]any); isTargetArray && exists {
+			return m.mergeArrays(targetArray, patchArray, path)
+		}
+	}
+
+	if exists {
+		if err := m.conflict(path, target, patch); err != nil {
+			return nil, err
+		}
+	}
+
+	return patch, nil
+}
+
+func (m *merger) mergeObjects(target, patch any, path string) (any, error) {
+	ordered := true
+	switch target.(type) {
+	case map[string]any:
+		ordered = false
+	case ifaces.Ordered:
+	default:
+		if target != nil {
+			// the patch replaces a value that is not an object
+			if err := m.conflict(path, target, patch); err != nil {
+				return nil, err
+			}
+		}
+
+		_, isMap := patch.(map[string]any)
+		ordered = !isMap
+		target = nil
+	}
+
+	result := make(JSONMapSlice, 0)
+	index := make(map[string]int)
+	for key, value := range objectItems(target) {
+		index[key] = len(result)
+		result = append(result, JSONMapItem{Key: key, Value: value})
+	}
+
+	removed := make(map[string]struct{})
+	for key, value := range objectItems(patch) {
+		pos, exists := index[key]
+		if value == nil {
+			if exists {
+				removed[key] = struct{}{}
+			}
+
+			continue
+		}
+
+		var current any
+		if exists {
+			current = result[pos].Value
+		}
+
+		merged, err := m.merge(current, exists, value, path+"/"+escapePointerToken(key))
+		if err != nil {
+			return nil, err
+		}
+
+		if exists {
+			result[pos].Value = merged
+			delete(removed, key)
+
+			continue
+		}
+
+		index[key] = len(result)
+		result = append(result, JSONMapItem{Key: key, Value: merged})
+	}
+
+	if len(removed) > 0 {
+		result = slices.DeleteFunc(result, func(item JSONMapItem) bool {
+			_, isRemoved := removed[item.Key]
+
+			return isRemoved
+		})
+	}
+
+	if ordered {
+		return result, nil
+	}
+
+	unordered := make(map[string]any, len(result))
+	for _, item := range result {
+		unordered[item.Key] = item.Value
+	}
+
+	return unordered, nil
+}
+
+func (m *merger) mergeArrays(target, patch []any, path string) (any, error) {
+	switch m.options.arrays {
+	case MergeArraysAppend:
+		result := make([]any, 0, len(target)+len(patch))
+		result = append(result, target...)
+		for i, value := range patch {
+			merged, err := m.merge(nil, false, value, path+"/"+strconv.Itoa(len(target)+i))
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, merged)
+		}
+
+		return result, nil
+
+	case MergeArraysByIndex:
+		result := make([]any, max(len(target), len(patch)))
+		copy(result, target)
+		for i, value := range patch {
+			exists := i < len(target)
+			var current any
+			if exists {
+				current = target[i]
+			}
+
+			merged, err := m.merge(current, exists, value, path+"/"+strconv.Itoa(i))
+			if err != nil {
+				return nil, err
+			}
+			result[i] = merged
+		}
+
+		return result, nil
+
+	default:
+		if err := m.conflict(path, target, patch); err != nil {
+			return nil, err
+		}
+
+		return patch, nil
+	}
+}
+
+// conflict reports a value in the target replaced by a different value.
+func (m *merger) conflict(path string, target, patch any) error {
+	if m.options.conflicts == nil || equalJSON(target, patch) {
+		return nil
+	}
+
+	return m.options.conflicts(MergeConflict{Path: path, Target: target, Patch: patch})
+}
+
+// readOrderedJSON reads any JSON value, with objects read as ordered maps.
+func readOrderedJSON(data []byte) (any, error) {
+	const (
+		prefix = `{"":`
+		suffix = `}`
+	)
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, errors.New("empty JSON document")
+	}
+
+	wrapped := make([]byte, 0, len(prefix)+len(data)+len(suffix))
+	wrapped = append(wrapped, prefix...)
+	wrapped = append(wrapped, data...)
+	wrapped = append(wrapped, suffix...)
+
+	var value JSONMapSlice
+	if err := ReadJSON(wrapped, &value); err != nil {
+		return nil, err
+	}
+
+	if len(value) != 1 {
+		return nil, errors.New("expected a single JSON value")
+	}
+
+	return value[0].Value, nil
+}
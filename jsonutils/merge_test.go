@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonutils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestMergeJSON(t *testing.T) {
+	t.Run("should merge with the semantics of RFC 7396", func(t *testing.T) {
+		// test cases from RFC 7396, appendix A
+		for _, test := range []struct {
+			target, patch, expected string
+		}{
+			{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+			{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+			{`{"a":"b"}`, `{"a":null}`, `{}`},
+			{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+			{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+			{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+			{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+			{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+			{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+			{`{"a":"b"}`, `["c"]`, `["c"]`},
+			{`{"a":"foo"}`, `null`, `null`},
+			{`{"a":"foo"}`, `"bar"`, `"bar"`},
+			{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+			{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+			{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		} {
+			merged, err := MergeJSON([]byte(test.target), []byte(test.patch))
+			require.NoError(t, err)
+			assert.EqualT(t, test.expected, string(merged), "merging %s into %s", test.patch, test.target)
+		}
+	})
+
+	t.Run("should maintain the order of keys", func(t *testing.T) {
+		merged, err := MergeJSON(
+			[]byte(`{"z":1,"b":{"y":2,"a":3},"m":4}`),
+			[]byte(`{"c":5,"b":{"x":6,"a":7},"z":null}`),
+		)
+		require.NoError(t, err)
+		assert.EqualT(t, `{"b":{"y":2,"a":7,"x":6},"m":4,"c":5}`, string(merged))
+	})
+
+	t.Run("should merge arrays with a strategy", func(t *testing.T) {
+		const (
+			target = `{"a":[{"x":1,"y":2},3]}`
+			patch  = `{"a":[{"y":null,"z":4},{"w":null}]}`
+		)
+
+		for _, test := range []struct {
+			strategy ArrayMergeStrategy
+			expected string
+		}{
+			{MergeArraysReplace, `{"a":[{"y":null,"z":4},{"w":null}]}`},
+			{MergeArraysAppend, `{"a":[{"x":1,"y":2},3,{"z":4},{}]}`},
+			{MergeArraysByIndex, `{"a":[{"x":1,"z":4},{}]}`},
+		} {
+			merged, err := MergeJSON([]byte(target), []byte(patch), WithMergeArrays(test.strategy))
+			require.NoError(t, err)
+			assert.EqualT(t, test.expected, string(merged))
+		}
+
+		merged, err := MergeJSON([]byte(`[1]`), []byte(`[null,2]`), WithMergeArrays(MergeArraysByIndex))
+		require.NoError(t, err)
+		assert.EqualT(t, `[null,2]`, string(merged))
+	})
+
+	t.Run("should report conflicts", func(t *testing.T) {
+		var conflicts []MergeConflict
+		collect := WithMergeConflicts(func(c MergeConflict) error {
+			conflicts = append(conflicts, c)
+
+			return nil
+		})
+
+		merged, err := MergeJSON(
+			[]byte(`{"a":1,"b":{"c/d":"x","e":[1,2]},"f":true,"g":"same","h":[1],"i":{"j":1}}`),
+			[]byte(`{"a":2,"b":{"c/d":"y","e":[2]},"f":null,"g":"same","h":[1],"i":"k"}`),
+			collect,
+		)
+		require.NoError(t, err)
+		assert.EqualT(t, `{"a":2,"b":{"c/d":"y","e":[2]},"g":"same","h":[1],"i":"k"}`, string(merged))
+
+		require.Len(t, conflicts, 4)
+		paths := make([]string, 0, len(conflicts))
+		for _, c := range conflicts {
+			paths = append(paths, c.Path)
+		}
+		assert.Equal(t, []string{"/a", "/b/c~1d", "/b/e", "/i"}, paths)
+		assert.Equal(t, MergeConflict{Path: "/a", Target: int64(1), Patch: int64(2)}, conflicts[0])
+
+		t.Run("should not report conflicts when merging arrays by index on equal elements", func(t *testing.T) {
+			conflicts = nil
+
+			_, err := MergeJSON([]byte(`[1,{"a":2}]`), []byte(`[1,{"a":3},4]`), collect, WithMergeArrays(MergeArraysByIndex))
+			require.NoError(t, err)
+			require.Len(t, conflicts, 1)
+			assert.EqualT(t, "/1/a", conflicts[0].Path)
+		})
+	})
+
+	t.Run("should stop on conflicts when the callback returns an error", func(t *testing.T) {
+		errConflict := errors.New("conflict")
+
+		_, err := MergeJSON([]byte(`{"a":{"b":1}}`), []byte(`{"a":{"b":2}}`),
+			WithMergeConflicts(func(MergeConflict) error { return errConflict }),
+		)
+		require.ErrorIs(t, err, errConflict)
+	})
+
+	t.Run("should error on invalid JSON", func(t *testing.T) {
+		for _, test := range []struct {
+			target, patch string
+		}{
+			{``, `{}`},
+			{`{}`, `{`},
+			{`1,"x":2`, `{}`},
+			{`{}`, ` `},
+		} {
+			_, err := MergeJSON([]byte(test.target), []byte(test.patch))
+			require.Error(t, err, "merging %q into %q", test.patch, test.target)
+		}
+	})
+}
+
+func TestMerge(t *testing.T) {
+	t.Run("should merge maps into maps", func(t *testing.T) {
+		target := map[string]any{"a": 1, "b": map[string]any{"c": 2}}
+		patch := map[string]any{"b": map[string]any{"c": nil, "d": 3}, "e": []any{4}}
+
+		merged, err := Merge(target, patch)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"a": 1, "b": map[string]any{"d": 3}, "e": []any{4}}, merged)
+
+		t.Run("should not modify the inputs", func(t *testing.T) {
+			assert.Equal(t, map[string]any{"a": 1, "b": map[string]any{"c": 2}}, target)
+		})
+	})
+
+	t.Run("should merge into ordered maps", func(t *testing.T) {
+		target := JSONMapSlice{{Key: "z", Value: 1}, {Key: "a", Value: 2}}
+		patch := map[string]any{"c": 3, "b": 4}
+
+		merged, err := Merge(target, patch)
+		require.NoError(t, err)
+		assert.Equal(t, JSONMapSlice{{Key: "z", Value: 1}, {Key: "a", Value: 2}, {Key: "b", Value: 4}, {Key: "c", Value: 3}}, merged)
+	})
+
+	t.Run("should tell equal JSON values regardless of the order of keys", func(t *testing.T) {
+		assert.TrueT(t, equalJSON(
+			JSONMapSlice{{Key: "a", Value: []any{1}}, {Key: "b", Value: "x"}},
+			map[string]any{"b": "x", "a": []any{1}},
+		))
+		assert.FalseT(t, equalJSON([]any{1}, []any{1, 2}))
+	})
+}
@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonutils
+
+import (
+	"encoding/json"
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/go-openapi/swag/jsonutils/adapters/ifaces"
+)
+
+// Helpers to work with dynamic JSON values, where objects are either a map[string]any
+// or an ordered map implementing [ifaces.Ordered], and arrays are []any.
+//
+// Updates are copy-on-write: containers are rebuilt rather than modified in place.
+
+func isObject(value any) bool {
+	switch value.(type) {
+	case map[string]any, ifaces.Ordered:
+		return true
+	default:
+		return false
+	}
+}
+
+// objectItems iterates over the keys of an object, in order for ordered maps, or sorted for maps.
+func objectItems(value any) iter.Seq2[string, any] {
+	switch object := value.(type) {
+	case ifaces.Ordered:
+		return object.OrderedItems()
+	case map[string]any:
+		return func(yield func(string, any) bool) {
+			for _, key := range slices.Sorted(maps.Keys(object)) {
+				if !yield(key, object[key]) {
+					return
+				}
+			}
+		}
+	default:
+		return func(func(string, any) bool) {}
+	}
+}
+
+// objectGet returns the value of a key in an object.
+func objectGet(object any, key string) (any, bool) {
+	if m, isMap := object.(map[string]any); isMap {
+		value, ok := m[key]
+
+		return value, ok
+	}
+
+	for k, value := range objectItems(object) {
+		if k == key {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+// objectSet returns a copy of an object with the value of a key set.
+//
+// An existing key keeps its position, and a new key comes last.
+func objectSet(object any, key string, value any) any {
+	return newObjectLike(object, func(yield func(string, any) bool) {
+		found := false
+		for k, v := range objectItems(object) {
+			if k == key {
+				found = true
+				v = value
+			}
+
+			if !yield(k, v) {
+				return
+			}
+		}
+
+		if !found {
+			yield(key, value)
+		}
+	})
+}
+
+// objectDelete returns a copy of an object without a key.
+func objectDelete(object any, key string) any {
+	return newObjectLike(object, func(yield func(string, any) bool) {
+		for k, v := range objectItems(object) {
+			if k == key {
+				continue
+			}
+
+			if !yield(k, v) {
+				return
+			}
+		}
+	})
+}
+
+// newObjectLike builds an object of the same type as like, with the given items.
+//
+// Ordered maps that implement [ifaces.SetOrdered] with a pointer receiver, such as [JSONMapSlice],
+// keep their type. Other ordered maps are replaced by a [JSONMapSlice].
+func newObjectLike(like any, items iter.Seq2[string, any]) any {
+	if _, isMap := like.(map[string]any); isMap {
+		return maps.Collect(items)
+	}
+
+	if t := reflect.TypeOf(like); t != nil {
+		isPointer := t.Kind() == reflect.Pointer
+		if isPointer {
+			t = t.Elem()
+		}
+
+		ptr := reflect.New(t)
+		if setter, ok := ptr.Interface().(ifaces.SetOrdered); ok {
+			setter.SetOrderedItems(items)
+			if elem := ptr.Elem(); elem.Kind() == reflect.Slice && elem.IsNil() {
+				// an empty object, not a null one
+				elem.Set(reflect.MakeSlice(t, 0, 0))
+			}
+
+			if isPointer {
+				return ptr.Interface()
+			}
+
+			return ptr.Elem().Interface()
+		}
+	}
+
+	object := make(JSONMapSlice, 0)
+	object.SetOrderedItems(items)
+
+	return object
+}
+
+// arrayInsert returns a copy of an array with a value inserted at some index.
+func arrayInsert(array []any, index int, value any) []any {
+	result := make([]any, 0, len(array)+1)
+	result = append(result, array[:index]...)
+	result = append(result, value)
+
+	return append(result, array[index:]...)
+}
+
+// arraySet returns a copy of an array with the value at some index replaced.
+func arraySet(array []any, index int, value any) []any {
+	result := slices.Clone(array)
+	result[index] = value
+
+	return result
+}
+
+// arrayDelete returns a copy of an array without the value at some index.
+func arrayDelete(array []any, index int) []any {
+	result := make([]any, 0, len(array)-1)
+	result = append(result, array[:index]...)
+
+	return append(result, array[index+1:]...)
+}
+
+// equalJSON tells if two dynamic JSON values are equal, regardless of the order of keys in objects
+// and of the go type used to represent numbers.
+func equalJSON(a, b any) bool {
+	if isObject(a) && isObject(b) {
+		left := maps.Collect(objectItems(a))
+		right := maps.Collect(objectItems(b))
+
+		return maps.EqualFunc(left, right, equalJSON)
+	}
+
+	left, isArray := a.([]any)
+	right, isOtherArray := b.([]any)
+	if isArray && isOtherArray {
+		return slices.EqualFunc(left, right, equalJSON)
+	}
+
+	x, isNumber := asFloat(a)
+	y, isOtherNumber := asFloat(b)
+	if isNumber && isOtherNumber {
+		return x == y
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// asFloat converts a number to a float64.
+func asFloat(value any) (float64, bool) {
+	switch number := value.(type) {
+	case json.Number:
+		f, err := number.Float64()
+
+		return f, err == nil
+	case float32, float64:
+		return reflect.ValueOf(number).Float(), true
+	case int, int8, int16, int32, int64:
+		return float64(reflect.ValueOf(number).Int()), true
+	case uint, uint8, uint16, uint32, uint64:
+		return float64(reflect.ValueOf(number).Uint()), true
+	default:
+		return 0, false
+	}
+}
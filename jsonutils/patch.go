@@ -0,0 +1,364 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonutils
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+)
+
+type patchError string
+
+func (e patchError) Error() string {
+	return string(e)
+}
+
+const (
+	// ErrPatchInvalid indicates a JSON patch operation that is not valid, e.g. an unknown operation.
+	ErrPatchInvalid patchError = "invalid JSON patch operation"
+
+	// ErrPatchPath indicates a JSON patch operation with a path that is not valid for the document,
+	// e.g. a path to a value that does not exist.
+	ErrPatchPath patchError = "invalid JSON patch path"
+
+	// ErrPatchTest indicates a JSON patch "test" operation that failed.
+	ErrPatchTest patchError = "JSON patch test failed"
+)
+
+// OperationError is the error returned by [ApplyPatch] when an operation fails.
+//
+// Use [errors.As] to retrieve the failed operation, and [errors.Is] to check the kind of error,
+// e.g. [ErrPatchTest].
+type OperationError struct {
+	// Index is the position of the operation in the [Patch].
+	Index int
+
+	Operation Operation
+
+	Err error
+}
+
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("JSON patch operation #%d (%s %q): %v", e.Index, e.Operation.Op, e.Operation.Path, e.Err)
+}
+
+func (e *OperationError) Unwrap() error {
+	return e.Err
+}
+
+// PatchOp is the kind of a JSON patch [Operation].
+type PatchOp string
+
+const (
+	OpAdd     PatchOp = "add"
+	OpRemove  PatchOp = "remove"
+	OpReplace PatchOp = "replace"
+	OpMove    PatchOp = "move"
+	OpCopy    PatchOp = "copy"
+	OpTest    PatchOp = "test"
+)
+
+// Operation is a JSON patch operation (RFC 6902).
+type Operation struct {
+	Op PatchOp
+
+	// Path is a JSON pointer (RFC 6901) to the location in the document where the operation applies.
+	Path string
+
+	// From is a JSON pointer to the source location of "move" and "copy" operations.
+	From string
+
+	// Value is the value used by "add", "replace" and "test" operations.
+	Value any
+}
+
+// MarshalJSON renders the [Operation] as a JSON object, with only the members that this kind of operation uses.
+func (o Operation) MarshalJSON() ([]byte, error) {
+	object := JSONMapSlice{
+		{Key: "op", Value: o.Op},
+		{Key: "path", Value: o.Path},
+	}
+
+	switch o.Op { //nolint:exhaustive // other operations have no other member
+	case OpMove, OpCopy:
+		object = append(object, JSONMapItem{Key: "from", Value: o.From})
+	case OpAdd, OpReplace, OpTest:
+		object = append(object, JSONMapItem{Key: "value", Value: o.Value})
+	}
+
+	return WriteJSON(object)
+}
+
+// UnmarshalJSON builds an [Operation] from a JSON object.
+//
+// Objects in the value of the operation are ordered maps.
+//
+// Members that are required by this kind of operation must be present: an error wrapping [ErrPatchInvalid]
+// is returned otherwise.
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	var object JSONMapSlice
+	if err := ReadJSON(data, &object); err != nil {
+		return err
+	}
+
+	var (
+		operation Operation
+		members   = make(map[string]bool, len(object))
+	)
+
+	for _, item := range object {
+		members[item.Key] = true
+
+		if item.Key == "value" {
+			operation.Value = item.Value
+
+			continue
+		}
+
+		var target *string
+		switch item.Key {
+		case "op":
+			target = (*string)(&operation.Op)
+		case "path":
+			target = &operation.Path
+		case "from":
+			target = &operation.From
+		default:
+			continue // other members are ignored
+		}
+
+		text, ok := item.Value.(string)
+		if !ok {
+			return fmt.Errorf("%w: member %q should be a string", ErrPatchInvalid, item.Key)
+		}
+		*target = text
+	}
+
+	required := []string{"op", "path"}
+	switch operation.Op { //nolint:exhaustive // other operations have no other member
+	case OpMove, OpCopy:
+		required = append(required, "from")
+	case OpAdd, OpReplace, OpTest:
+		required = append(required, "value")
+	}
+
+	for _, member := range required {
+		if !members[member] {
+			return fmt.Errorf("%w: missing member %q", ErrPatchInvalid, member)
+		}
+	}
+
+	*o = operation
+
+	return nil
+}
+
+// Patch is a JSON patch (RFC 6902), i.e. a sequence of operations to apply to a JSON document.
+//
+// A [Patch] marshals to and unmarshals from JSON like any other value, e.g. with [WriteJSON] and [ReadJSON].
+type Patch []Operation
+
+// ApplyPatch applies a JSON patch (RFC 6902) to a document and returns the patched document.
+//
+// The document is a dynamic JSON value, where objects are either ordered maps such as
+// [JSONMapSlice] or map[string]any, and arrays are []any. Objects keep their type, e.g. a YAMLMapSlice
+// from package yamlutils remains a YAMLMapSlice, and the order of untouched keys is maintained.
+// New keys are added last.
+//
+// The document is not modified, but the patched document may share some values with it and with the patch.
+//
+// Operations are applied in sequence, and the patch is applied as a whole or not at all:
+// if some operation fails, ApplyPatch returns an [*OperationError] that wraps one of
+// [ErrPatchInvalid], [ErrPatchPath] or [ErrPatchTest].
+func ApplyPatch(doc any, patch Patch) (any, error) {
+	for i, operation := range patch {
+		patched, err := operation.apply(doc)
+		if err != nil {
+			return nil, &OperationError{Index: i, Operation: operation, Err: err}
+		}
+
+		doc = patched
+	}
+
+	return doc, nil
+}
+
+// Diff returns a JSON patch that turns a document into another one, when applied with [ApplyPatch].
+//
+// Like [ApplyPatch], Diff works with dynamic JSON values, with objects represented by ordered maps or map[string]any.
+//
+// The order of keys is not significant: the patch adds new keys, removes missing keys and replaces changed values.
+// Arrays are compared element by element, with elements added or removed at the end.
+func Diff(from, to any) Patch {
+	patch := make(Patch, 0)
+	diffValues(&patch, "", from, to)
+
+	return patch
+}
+
+func (o Operation) apply(doc any) (any, error) {
+	path, err := parsePointer(o.Path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPatchPath, err)
+	}
+
+	switch o.Op {
+	case OpAdd:
+		return wrapPathError(patchAdd(doc, path, o.Value))
+
+	case OpRemove:
+		return wrapPathError(deleteAt(doc, path))
+
+	case OpReplace:
+		return wrapPathError(patchReplace(doc, path, o.Value))
+
+	case OpMove, OpCopy:
+		from, err := parsePointer(o.From)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrPatchPath, err)
+		}
+
+		value, err := getAt(doc, from)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrPatchPath, err)
+		}
+
+		if o.Op == OpCopy {
+			return wrapPathError(patchAdd(doc, path, value))
+		}
+
+		if o.Path == o.From {
+			return doc, nil
+		}
+
+		if strings.HasPrefix(o.Path, o.From+"/") {
+			return nil, fmt.Errorf("%w: cannot move %q into one of its children", ErrPatchInvalid, o.From)
+		}
+
+		doc, err = deleteAt(doc, from)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrPatchPath, err)
+		}
+
+		return wrapPathError(patchAdd(doc, path, value))
+
+	case OpTest:
+		value, err := getAt(doc, path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrPatchPath, err)
+		}
+
+		if !equalJSON(value, o.Value) {
+			return nil, ErrPatchTest
+		}
+
+		return doc, nil
+
+	default:
+		return nil, fmt.Errorf("%w: unknown operation %q", ErrPatchInvalid, o.Op)
+	}
+}
+
+func wrapPathError(doc any, err error) (any, error) {
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPatchPath, err)
+	}
+
+	return doc, nil
+}
+
+func patchAdd(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return updateAt(doc, path, func(container any, token string) (any, error) {
+		if isObject(container) {
+			return objectSet(container, token, value), nil
+		}
+
+		array, isArray := container.([]any)
+		if !isArray {
+			return nil, fmt.Errorf("cannot add %q to a value that is not an object or an array", token)
+		}
+
+		index, err := arrayIndex(token, len(array), true)
+		if err != nil {
+			return nil, err
+		}
+
+		return arrayInsert(array, index, value), nil
+	})
+}
+
+func patchReplace(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return updateAt(doc, path, func(container any, token string) (any, error) {
+		if _, err := childAt(container, token); err != nil {
+			return nil, err
+		}
+
+		return replaceChild(container, token, value), nil
+	})
+}
+
+func diffValues(patch *Patch, path string, from, to any) {
+	fromArray, isArray := from.([]any)
+	toArray, isOtherArray := to.([]any)
+
+	switch {
+	case isObject(from) && isObject(to):
+		diffObjects(patch, path, from, to)
+	case isArray && isOtherArray:
+		diffArrays(patch, path, fromArray, toArray)
+	case !equalJSON(from, to):
+		*patch = append(*patch, Operation{Op: OpReplace, Path: path, Value: to})
+	}
+}
+
+func diffObjects(patch *Patch, path string, from, to any) {
+	fromKeys := maps.Collect(objectItems(from))
+	toKeys := maps.Collect(objectItems(to))
+
+	for key, value := range objectItems(from) {
+		keyPath := path + "/" + escapePointerToken(key)
+
+		other, ok := toKeys[key]
+		if !ok {
+			*patch = append(*patch, Operation{Op: OpRemove, Path: keyPath})
+
+			continue
+		}
+
+		diffValues(patch, keyPath, value, other)
+	}
+
+	for key, value := range objectItems(to) {
+		if _, ok := fromKeys[key]; ok {
+			continue
+		}
+
+		*patch = append(*patch, Operation{Op: OpAdd, Path: path + "/" + escapePointerToken(key), Value: value})
+	}
+}
+
+func diffArrays(patch *Patch, path string, from, to []any) {
+	common := min(len(from), len(to))
+	for i := range common {
+		diffValues(patch, path+"/"+strconv.Itoa(i), from[i], to[i])
+	}
+
+	for i := common; i < len(to); i++ {
+		*patch = append(*patch, Operation{Op: OpAdd, Path: path + "/" + strconv.Itoa(i), Value: to[i]})
+	}
+
+	for i := len(from) - 1; i >= common; i-- {
+		*patch = append(*patch, Operation{Op: OpRemove, Path: path + "/" + strconv.Itoa(i)})
+	}
+}
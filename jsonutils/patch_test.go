@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonutils
+
+import (
+	"errors"
+	"testing"
+
+	stdlib "github.com/go-openapi/swag/jsonutils/adapters/stdlib/json"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+// applyJSON applies a JSON patch to a JSON document, with objects read as ordered maps.
+func applyJSON(t *testing.T, doc, patch string) (string, error) {
+	t.Helper()
+
+	value, err := readOrderedJSON([]byte(doc))
+	require.NoError(t, err)
+
+	var p Patch
+	require.NoError(t, ReadJSON([]byte(patch), &p))
+
+	patched, err := ApplyPatch(value, p)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := WriteJSON(patched)
+	require.NoError(t, err)
+
+	return string(b), nil
+}
+
+func TestApplyPatch(t *testing.T) {
+	t.Run("should apply operations with the semantics of RFC 6902", func(t *testing.T) {
+		// test cases from RFC 6902, appendix A
+		for _, test := range []struct {
+			doc, patch, expected string
+		}{
+			{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux"}]`, `{"foo":"bar","baz":"qux"}`},
+			{`{"foo":["bar","baz"]}`, `[{"op":"add","path":"/foo/1","value":"qux"}]`, `{"foo":["bar","qux","baz"]}`},
+			{`{"baz":"qux","foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, `{"foo":"bar"}`},
+			{`{"foo":["bar","qux","baz"]}`, `[{"op":"remove","path":"/foo/1"}]`, `{"foo":["bar","baz"]}`},
+			{`{"baz":"qux","foo":"bar"}`, `[{"op":"replace","path":"/baz","value":"boo"}]`, `{"baz":"boo","foo":"bar"}`},
+			{
+				`{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+				`[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+				`{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+			},
+			{`{"foo":["all","grass","cows","eat"]}`, `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`, `{"foo":["all","cows","eat","grass"]}`},
+			{`{"foo":["bar"]}`, `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`, `{"foo":["bar",["abc","def"]]}`},
+			{`{"foo":"bar"}`, `[{"op":"add","path":"/child","value":{"grandchild":{}}}]`, `{"foo":"bar","child":{"grandchild":{}}}`},
+			{`{"foo":"bar"}`, `[{"op":"add","path":"/baz","value":"qux","xyz":123}]`, `{"foo":"bar","baz":"qux"}`},
+			{`{"foo":null}`, `[{"op":"test","path":"/foo","value":null}]`, `{"foo":null}`},
+			{`{"foo":1}`, `[{"op":"test","path":"/foo","value":1.0}]`, `{"foo":1}`},
+			{`{"/":9,"~1":10}`, `[{"op":"test","path":"/~01","value":10}]`, `{"/":9,"~1":10}`},
+			{`["a",{"b":"c"}]`, `[{"op":"copy","from":"/1","path":"/-"},{"op":"replace","path":"/2/b","value":"d"}]`, `["a",{"b":"c"},{"b":"d"}]`},
+			{`{"a":1}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+			{`{"a":1}`, `[{"op":"move","from":"/a","path":"/a"}]`, `{"a":1}`},
+			{
+				`{"baz":"value","foo":["a",2,"c"]}`,
+				`[{"op":"test","path":"/baz","value":"value"},{"op":"test","path":"/foo","value":["a",2,"c"]}]`,
+				`{"baz":"value","foo":["a",2,"c"]}`,
+			},
+		} {
+			patched, err := applyJSON(t, test.doc, test.patch)
+			require.NoError(t, err, "applying %s to %s", test.patch, test.doc)
+			assert.EqualT(t, test.expected, patched, "applying %s to %s", test.patch, test.doc)
+		}
+	})
+
+	t.Run("should report typed errors", func(t *testing.T) {
+		for _, test := range []struct {
+			doc, patch string
+			index      int
+			expected   error
+		}{
+			{`{"foo":"bar"}`, `[{"op":"add","path":"/baz/bat","value":"qux"}]`, 0, ErrPatchPath},
+			{`{"baz":"qux"}`, `[{"op":"test","path":"/baz","value":"bar"}]`, 0, ErrPatchTest},
+			{`{"foo":["bar"]}`, `[{"op":"test","path":"/foo","value":["bar"]},{"op":"add","path":"/foo/2","value":1}]`, 1, ErrPatchPath},
+			{`{"foo":["bar"]}`, `[{"op":"remove","path":"/foo/01"}]`, 0, ErrPatchPath},
+			{`{"foo":["bar"]}`, `[{"op":"remove","path":"/foo/-"}]`, 0, ErrPatchPath},
+			{`{"foo":"bar"}`, `[{"op":"remove","path":"/baz"}]`, 0, ErrPatchPath},
+			{`{"foo":"bar"}`, `[{"op":"remove","path":""}]`, 0, ErrPatchPath},
+			{`{"foo":"bar"}`, `[{"op":"replace","path":"/baz","value":1}]`, 0, ErrPatchPath},
+			{`{"foo":"bar"}`, `[{"op":"replace","path":"/foo/bar","value":1}]`, 0, ErrPatchPath},
+			{`{"foo":"bar"}`, `[{"op":"test","path":"foo","value":1}]`, 0, ErrPatchPath},
+			{`{"foo":"bar"}`, `[{"op":"test","path":"/~2","value":1}]`, 0, ErrPatchPath},
+			{`{"foo":"bar"}`, `[{"op":"copy","from":"/baz","path":"/foo"}]`, 0, ErrPatchPath},
+			{`{"foo":"bar"}`, `[{"op":"move","from":"baz","path":"/foo"}]`, 0, ErrPatchPath},
+			{`{"foo":{"a":1}}`, `[{"op":"move","from":"/foo","path":"/foo/a/b"}]`, 0, ErrPatchInvalid},
+			{`{"foo":"bar"}`, `[{"op":"frobnicate","path":"/foo"}]`, 0, ErrPatchInvalid},
+		} {
+			_, err := applyJSON(t, test.doc, test.patch)
+			require.Error(t, err)
+			require.ErrorIs(t, err, test.expected, "applying %s to %s", test.patch, test.doc)
+
+			var operationErr *OperationError
+			require.ErrorAs(t, err, &operationErr)
+			assert.EqualT(t, test.index, operationErr.Index)
+			assert.StringContainsT(t, err.Error(), "JSON patch operation #")
+		}
+	})
+
+	t.Run("should reject operations with missing members", func(t *testing.T) {
+		for _, patch := range []string{
+			`[{"path":"/a"}]`,
+			`[{"op":"remove"}]`,
+			`[{"op":"add","path":"/a"}]`,
+			`[{"op":"copy","path":"/a"}]`,
+			`[{"op":1,"path":"/a"}]`,
+		} {
+			var p Patch
+			err := ReadJSON([]byte(patch), &p)
+			require.Error(t, err)
+			require.ErrorIs(t, err, ErrPatchInvalid, patch)
+		}
+	})
+
+	t.Run("should keep the order of keys and the type of ordered maps", func(t *testing.T) {
+		doc := JSONMapSlice{
+			{Key: "z", Value: 1},
+			{Key: "b", Value: stdlib.MapSlice{{Key: "y", Value: 2}, {Key: "a", Value: 3}}},
+			{Key: "m", Value: map[string]any{"k": 4}},
+		}
+		patch := Patch{
+			{Op: OpReplace, Path: "/b/y", Value: 5},
+			{Op: OpAdd, Path: "/b/c", Value: 6},
+			{Op: OpRemove, Path: "/m/k"},
+			{Op: OpAdd, Path: "/c", Value: 7},
+		}
+
+		patched, err := ApplyPatch(doc, patch)
+		require.NoError(t, err)
+
+		assert.Equal(t, JSONMapSlice{
+			{Key: "z", Value: 1},
+			{Key: "b", Value: stdlib.MapSlice{{Key: "y", Value: 5}, {Key: "a", Value: 3}, {Key: "c", Value: 6}}},
+			{Key: "m", Value: map[string]any{}},
+			{Key: "c", Value: 7},
+		}, patched)
+
+		t.Run("should not modify the document", func(t *testing.T) {
+			assert.Equal(t, JSONMapSlice{
+				{Key: "z", Value: 1},
+				{Key: "b", Value: stdlib.MapSlice{{Key: "y", Value: 2}, {Key: "a", Value: 3}}},
+				{Key: "m", Value: map[string]any{"k": 4}},
+			}, doc)
+		})
+
+		t.Run("should keep an empty object", func(t *testing.T) {
+			patched, err := ApplyPatch(JSONMapSlice{{Key: "a", Value: 1}}, Patch{{Op: OpRemove, Path: "/a"}})
+			require.NoError(t, err)
+
+			b, err := WriteJSON(patched)
+			require.NoError(t, err)
+			assert.EqualT(t, `{}`, string(b))
+		})
+
+		t.Run("should keep the type of pointers to ordered maps", func(t *testing.T) {
+			patched, err := ApplyPatch(&JSONMapSlice{{Key: "a", Value: 1}}, Patch{{Op: OpAdd, Path: "/b", Value: 2}})
+			require.NoError(t, err)
+			assert.Equal(t, &JSONMapSlice{{Key: "a", Value: 1}, {Key: "b", Value: 2}}, patched)
+		})
+	})
+}
+
+func TestPatchJSON(t *testing.T) {
+	t.Run("should marshal only the members used by operations", func(t *testing.T) {
+		patch := Patch{
+			{Op: OpAdd, Path: "/a", Value: nil},
+			{Op: OpRemove, Path: "/b", Value: "ignored"},
+			{Op: OpMove, Path: "/c", From: "/d"},
+			{Op: OpTest, Path: "/e", Value: JSONMapSlice{{Key: "z", Value: 1}, {Key: "y", Value: 2}}},
+		}
+
+		b, err := WriteJSON(patch)
+		require.NoError(t, err)
+		assert.EqualT(t,
+			`[{"op":"add","path":"/a","value":null},{"op":"remove","path":"/b"},{"op":"move","path":"/c","from":"/d"},`+
+				`{"op":"test","path":"/e","value":{"z":1,"y":2}}]`,
+			string(b),
+		)
+
+		var roundTrip Patch
+		require.NoError(t, ReadJSON(b, &roundTrip))
+		require.Len(t, roundTrip, len(patch))
+		for i, operation := range roundTrip {
+			assert.EqualT(t, patch[i].Op, operation.Op)
+			assert.EqualT(t, patch[i].Path, operation.Path)
+			assert.EqualT(t, patch[i].From, operation.From)
+		}
+		assert.Nil(t, roundTrip[0].Value)
+		assert.TrueT(t, equalJSON(patch[3].Value, roundTrip[3].Value))
+	})
+}
+
+func TestDiff(t *testing.T) {
+	for _, test := range []struct {
+		from, to string
+	}{
+		{`{"a":1,"b":{"c":[1,2,3],"d":"x"},"e":null}`, `{"b":{"c":[1,4],"d":"x","f":{"g":true}},"e":null,"h":[]}`},
+		{`[1,{"a":1}]`, `[1,{"a":1},{"b":2},3]`},
+		{`{"a/b":1,"c~d":2}`, `{"a/b":3}`},
+		{`{"a":1}`, `[1]`},
+		{`{"a":1}`, `{"a":1}`},
+		{`{"a":1,"b":2}`, `{"b":2,"a":1}`},
+	} {
+		from, err := readOrderedJSON([]byte(test.from))
+		require.NoError(t, err)
+		to, err := readOrderedJSON([]byte(test.to))
+		require.NoError(t, err)
+
+		patch := Diff(from, to)
+
+		patched, err := ApplyPatch(from, patch)
+		require.NoError(t, err)
+		assert.TrueT(t, equalJSON(to, patched), "diff from %s to %s", test.from, test.to)
+	}
+
+	t.Run("should produce minimal operations on objects", func(t *testing.T) {
+		patch := Diff(
+			JSONMapSlice{{Key: "a", Value: 1}, {Key: "b", Value: []any{1, 2}}},
+			JSONMapSlice{{Key: "b", Value: []any{1}}, {Key: "c/d", Value: "x"}, {Key: "a", Value: 1.0}},
+		)
+
+		assert.Equal(t, Patch{
+			{Op: OpRemove, Path: "/b/1"},
+			{Op: OpAdd, Path: "/c~1d", Value: "x"},
+		}, patch)
+	})
+
+	t.Run("should produce an empty patch for equal documents", func(t *testing.T) {
+		patch := Diff(map[string]any{"a": []any{int64(1)}}, JSONMapSlice{{Key: "a", Value: []any{1.0}}})
+		assert.Empty(t, patch)
+
+		b, err := WriteJSON(patch)
+		require.NoError(t, err)
+		assert.EqualT(t, `[]`, string(b))
+	})
+}
+
+func TestOperationError(t *testing.T) {
+	err := &OperationError{Index: 2, Operation: Operation{Op: OpTest, Path: "/a"}, Err: ErrPatchTest}
+	assert.EqualT(t, `JSON patch operation #2 (test "/a"): JSON patch test failed`, err.Error())
+	assert.TrueT(t, errors.Is(err, ErrPatchTest))
+}
@@ -0,0 +1,384 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonutils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type pointerError string
+
+func (e pointerError) Error() string {
+	return string(e)
+}
+
+const (
+	// ErrPointer indicates a JSON pointer that is not valid, e.g. that does not start with "/".
+	ErrPointer pointerError = "invalid JSON pointer"
+
+	// ErrPointerNotFound indicates a JSON pointer that does not resolve against a document,
+	// e.g. to a key that does not exist.
+	ErrPointerNotFound pointerError = "JSON pointer not found"
+
+	// ErrSkipChildren may be returned by the callback of [Walk] to skip the children of the current value.
+	ErrSkipChildren pointerError = "skip children"
+)
+
+// PointerOption selects options for [Set].
+type PointerOption func(*pointerOptions)
+
+type pointerOptions struct {
+	createIntermediates bool
+}
+
+// WithIntermediates creates the missing intermediate objects when setting a value.
+//
+// Intermediate objects have the type of their parent object, e.g. [JSONMapSlice].
+// Only keys in objects may be created: missing elements in arrays are never created.
+func WithIntermediates(enabled bool) PointerOption {
+	return func(o *pointerOptions) {
+		o.createIntermediates = enabled
+	}
+}
+
+// Get returns the value at the location of a JSON pointer (RFC 6901) in a document.
+//
+// The document is a dynamic JSON value, where objects are either ordered maps implementing [ifaces.Ordered],
+// such as [JSONMapSlice], YAMLMapSlice from package yamlutils or the MapSlice of JSON adapters,
+// or map[string]any, and arrays are []any.
+//
+// The empty pointer refers to the whole document.
+//
+// Get returns an error wrapping [ErrPointer] if the pointer is not valid,
+// or [ErrPointerNotFound] if the pointer does not resolve.
+func Get(doc any, pointer string) (any, error) {
+	path, err := parsePointer(pointer)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPointer, err)
+	}
+
+	value, err := getAt(doc, path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPointerNotFound, err)
+	}
+
+	return value, nil
+}
+
+// Set sets the value at the location of a JSON pointer (RFC 6901) in a document, and returns the updated document.
+//
+// See [Get] about the representation of the document. Objects keep their type and the order of keys is maintained:
+// an existing key keeps its position, and a new key is added last. In arrays, the pointer may refer to an existing
+// element, which is replaced, or to the end of the array ("-" or the length of the array), where the value is appended.
+//
+// The document is not modified, but the updated document may share some values with it.
+//
+// By default, the parent of the location must exist. See [WithIntermediates] to create missing objects.
+func Set(doc any, pointer string, value any, opts ...PointerOption) (any, error) {
+	var o pointerOptions
+	for _, apply := range opts {
+		apply(&o)
+	}
+
+	path, err := parsePointer(pointer)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPointer, err)
+	}
+
+	if doc == nil && len(path) > 0 && o.createIntermediates {
+		doc = make(JSONMapSlice, 0)
+	}
+
+	updated, err := setAt(doc, path, value, o.createIntermediates)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPointerNotFound, err)
+	}
+
+	return updated, nil
+}
+
+// Delete removes the value at the location of a JSON pointer (RFC 6901) in a document, and returns the updated document.
+//
+// See [Get] about the representation of the document, and [Set] about how the document is updated.
+//
+// The whole document can't be deleted.
+func Delete(doc any, pointer string) (any, error) {
+	path, err := parsePointer(pointer)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPointer, err)
+	}
+
+	updated, err := deleteAt(doc, path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPointerNotFound, err)
+	}
+
+	return updated, nil
+}
+
+// Walk visits all the values in a document, depth first, with their JSON pointer (RFC 6901).
+//
+// See [Get] about the representation of the document. Objects are visited before their children,
+// in the order of their keys, or sorted by key for map[string]any.
+//
+// When the callback returns [ErrSkipChildren], the children of the current value are not visited.
+// When the callback returns any other error, the walk stops and Walk returns this error.
+func Walk(doc any, walkFn func(pointer string, value any) error) error {
+	err := walk("", doc, walkFn)
+	if errors.Is(err, ErrSkipChildren) {
+		return nil
+	}
+
+	return err
+}
+
+func walk(pointer string, value any, walkFn func(string, any) error) error {
+	if err := walkFn(pointer, value); err != nil {
+		return err
+	}
+
+	if isObject(value) {
+		for key, child := range objectItems(value) {
+			if err := walkChild(pointer+"/"+escapePointerToken(key), child, walkFn); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	array, isArray := value.([]any)
+	if !isArray {
+		return nil
+	}
+
+	for i, child := range array {
+		if err := walkChild(pointer+"/"+strconv.Itoa(i), child, walkFn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func walkChild(pointer string, child any, walkFn func(string, any) error) error {
+	err := walk(pointer, child, walkFn)
+	if errors.Is(err, ErrSkipChildren) {
+		return nil
+	}
+
+	return err
+}
+
+var (
+	pointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+func escapePointerToken(token string) string {
+	return pointerEscaper.Replace(token)
+}
+
+// parsePointer splits a JSON pointer (RFC 6901) into unescaped reference tokens.
+//
+// The empty pointer refers to the whole document and yields no token.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("JSON pointer %q should start with '/'", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		for j := range len(token) {
+			if token[j] == '~' && (j+1 == len(token) || (token[j+1] != '0' && token[j+1] != '1')) {
+				return nil, fmt.Errorf("JSON pointer %q has an invalid escape sequence", pointer)
+			}
+		}
+
+		tokens[i] = pointerUnescaper.Replace(token)
+	}
+
+	return tokens, nil
+}
+
+// arrayIndex parses a reference token as the index of an element of an array of some length.
+//
+// When allowEnd is true, the index may be equal to the length of the array, e.g. to add a new element.
+// The "-" token refers to this position.
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" && allowEnd {
+		return length, nil
+	}
+
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil || index > length || (index == length && !allowEnd) {
+		return 0, fmt.Errorf("array index %q out of range", token)
+	}
+
+	return index, nil
+}
+
+// getAt returns the value at some location in a document.
+func getAt(doc any, path []string) (any, error) {
+	for _, token := range path {
+		child, err := childAt(doc, token)
+		if err != nil {
+			return nil, err
+		}
+
+		doc = child
+	}
+
+	return doc, nil
+}
+
+// updateAt returns a copy of a document, where update replaces the container of the last token of the path.
+func updateAt(doc any, path []string, update func(container any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return update(doc, path[0])
+	}
+
+	child, err := childAt(doc, path[0])
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := updateAt(child, path[1:], update)
+	if err != nil {
+		return nil, err
+	}
+
+	return replaceChild(doc, path[0], updated), nil
+}
+
+// childAt returns the value of a key in an object, or of an element in an array.
+func childAt(container any, token string) (any, error) {
+	if isObject(container) {
+		value, ok := objectGet(container, token)
+		if !ok {
+			return nil, fmt.Errorf("key %q not found", token)
+		}
+
+		return value, nil
+	}
+
+	array, isArray := container.([]any)
+	if !isArray {
+		return nil, fmt.Errorf("cannot find %q in a value that is not an object or an array", token)
+	}
+
+	index, err := arrayIndex(token, len(array), false)
+	if err != nil {
+		return nil, err
+	}
+
+	return array[index], nil
+}
+
+// replaceChild replaces an existing value in a container, as located by childAt.
+func replaceChild(container any, token string, value any) any {
+	if isObject(container) {
+		return objectSet(container, token, value)
+	}
+
+	array := container.([]any)
+	index, _ := strconv.Atoi(token)
+
+	return arraySet(array, index, value)
+}
+
+// deleteAt returns a copy of a document without the value at some location.
+func deleteAt(doc any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, errors.New("cannot remove the whole document")
+	}
+
+	return updateAt(doc, path, func(container any, token string) (any, error) {
+		if isObject(container) {
+			if _, ok := objectGet(container, token); !ok {
+				return nil, fmt.Errorf("key %q not found", token)
+			}
+
+			return objectDelete(container, token), nil
+		}
+
+		array, isArray := container.([]any)
+		if !isArray {
+			return nil, fmt.Errorf("cannot remove %q from a value that is not an object or an array", token)
+		}
+
+		index, err := arrayIndex(token, len(array), false)
+		if err != nil {
+			return nil, err
+		}
+
+		return arrayDelete(array, index), nil
+	})
+}
+
+// setAt returns a copy of a document with the value at some location set.
+func setAt(doc any, path []string, value any, createIntermediates bool) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	token := path[0]
+	if len(path) == 1 {
+		return setChild(doc, token, value)
+	}
+
+	child, err := childAt(doc, token)
+	switch {
+	case err == nil && (child != nil || !createIntermediates):
+	case createIntermediates && isObject(doc):
+		child = newObjectLike(doc, func(func(string, any) bool) {})
+	default:
+		if err == nil {
+			err = fmt.Errorf("cannot set a value in null at %q", token)
+		}
+
+		return nil, err
+	}
+
+	updated, err := setAt(child, path[1:], value, createIntermediates)
+	if err != nil {
+		return nil, err
+	}
+
+	return replaceChild(doc, token, updated), nil
+}
+
+// setChild returns a copy of a container with a key set, or an element replaced or appended.
+func setChild(container any, token string, value any) (any, error) {
+	if isObject(container) {
+		return objectSet(container, token, value), nil
+	}
+
+	array, isArray := container.([]any)
+	if !isArray {
+		return nil, fmt.Errorf("cannot set %q in a value that is not an object or an array", token)
+	}
+
+	index, err := arrayIndex(token, len(array), true)
+	if err != nil {
+		return nil, err
+	}
+
+	if index == len(array) {
+		return arrayInsert(array, index, value), nil
+	}
+
+	return arraySet(array, index, value), nil
+}
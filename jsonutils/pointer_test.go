@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonutils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestParsePointer(t *testing.T) {
+	for pointer, expected := range map[string][]string{
+		"":        nil,
+		"/":       {""},
+		"/a/b":    {"a", "b"},
+		"/a~1b/0": {"a/b", "0"},
+		"/~01":    {"~1"},
+		"/m~0n":   {"m~n"},
+	} {
+		tokens, err := parsePointer(pointer)
+		require.NoError(t, err)
+		assert.Equal(t, expected, tokens, pointer)
+	}
+
+	for _, pointer := range []string{"a", "/~", "/~2", "/a~"} {
+		_, err := parsePointer(pointer)
+		require.Error(t, err, pointer)
+	}
+}
+
+func TestArrayIndex(t *testing.T) {
+	index, err := arrayIndex("-", 3, true)
+	require.NoError(t, err)
+	assert.EqualT(t, 3, index)
+
+	index, err = arrayIndex("10", 11, false)
+	require.NoError(t, err)
+	assert.EqualT(t, 10, index)
+
+	for _, token := range []string{"-", "", "01", "+1", "-1", "3", "99999999999999999999"} {
+		_, err := arrayIndex(token, 3, false)
+		require.Error(t, err, token)
+	}
+}
+
+func TestPointerGetSetDelete(t *testing.T) {
+	const jazon = `{"z":{"y":[1,{"x":"a"}]},"a/b":true,"m~n":null}`
+
+	var doc JSONMapSlice
+	require.NoError(t, ReadJSON([]byte(jazon), &doc))
+
+	t.Run("should Get values", func(t *testing.T) {
+		value, err := Get(doc, "/z/y/1/x")
+		require.NoError(t, err)
+		assert.Equal(t, "a", value)
+
+		value, err = Get(doc, "/a~1b")
+		require.NoError(t, err)
+		assert.Equal(t, true, value)
+
+		value, err = Get(doc, "/m~0n")
+		require.NoError(t, err)
+		assert.Nil(t, value)
+
+		value, err = Get(doc, "")
+		require.NoError(t, err)
+		assert.Equal(t, doc, value)
+	})
+
+	t.Run("should not Get missing values", func(t *testing.T) {
+		for _, pointer := range []string{"/w", "/z/y/2", "/z/y/-", "/a~1b/c"} {
+			_, err := Get(doc, pointer)
+			require.ErrorIs(t, err, ErrPointerNotFound, pointer)
+		}
+
+		_, err := Get(doc, "z")
+		require.ErrorIs(t, err, ErrPointer)
+	})
+
+	t.Run("should Set values and maintain the order of keys", func(t *testing.T) {
+		updated, err := Set(doc, "/z/y/1/x", "b")
+		require.NoError(t, err)
+		updated, err = Set(updated, "/z/y/-", 3)
+		require.NoError(t, err)
+		updated, err = Set(updated, "/c", "new")
+		require.NoError(t, err)
+
+		assert.IsType(t, JSONMapSlice{}, updated)
+		jazonUpdated, err := WriteJSON(updated)
+		require.NoError(t, err)
+		assert.EqualT(t, `{"z":{"y":[1,{"x":"b"},3]},"a/b":true,"m~n":null,"c":"new"}`, string(jazonUpdated))
+
+		t.Run("the original document should not be modified", func(t *testing.T) {
+			jazonOriginal, err := WriteJSON(doc)
+			require.NoError(t, err)
+			assert.EqualT(t, jazon, string(jazonOriginal))
+		})
+	})
+
+	t.Run("should Set values with intermediate objects", func(t *testing.T) {
+		_, err := Set(doc, "/w/v", 1)
+		require.ErrorIs(t, err, ErrPointerNotFound)
+
+		updated, err := Set(doc, "/w/v", 1, WithIntermediates(true))
+		require.NoError(t, err)
+		updated, err = Set(updated, "/m~0n/u", 2, WithIntermediates(true))
+		require.NoError(t, err)
+
+		value, err := Get(updated, "/w")
+		require.NoError(t, err)
+		assert.IsType(t, JSONMapSlice{}, value)
+
+		jazonUpdated, err := WriteJSON(updated)
+		require.NoError(t, err)
+		assert.EqualT(t, `{"z":{"y":[1,{"x":"a"}]},"a/b":true,"m~n":{"u":2},"w":{"v":1}}`, string(jazonUpdated))
+
+		t.Run("should create a document from nil", func(t *testing.T) {
+			created, err := Set(nil, "/a/b", "c", WithIntermediates(true))
+			require.NoError(t, err)
+			assert.Equal(t, JSONMapSlice{{Key: "a", Value: JSONMapSlice{{Key: "b", Value: "c"}}}}, created)
+		})
+
+		t.Run("should not create array elements", func(t *testing.T) {
+			_, err := Set(doc, "/z/y/5/x", 1, WithIntermediates(true))
+			require.ErrorIs(t, err, ErrPointerNotFound)
+		})
+	})
+
+	t.Run("should Set values in a map", func(t *testing.T) {
+		updated, err := Set(map[string]any{"a": map[string]any{}}, "/a/b", 1)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"a": map[string]any{"b": 1}}, updated)
+	})
+
+	t.Run("should Delete values", func(t *testing.T) {
+		updated, err := Delete(doc, "/z/y/0")
+		require.NoError(t, err)
+		updated, err = Delete(updated, "/a~1b")
+		require.NoError(t, err)
+
+		jazonUpdated, err := WriteJSON(updated)
+		require.NoError(t, err)
+		assert.EqualT(t, `{"z":{"y":[{"x":"a"}]},"m~n":null}`, string(jazonUpdated))
+
+		_, err = Delete(doc, "/w")
+		require.ErrorIs(t, err, ErrPointerNotFound)
+
+		_, err = Delete(doc, "")
+		require.ErrorIs(t, err, ErrPointerNotFound)
+
+		_, err = Delete(doc, "/~2")
+		require.ErrorIs(t, err, ErrPointer)
+	})
+}
+
+func TestPointerWalk(t *testing.T) {
+	var doc JSONMapSlice
+	require.NoError(t, ReadJSON([]byte(`{"z":{"y":[1,{"x":"a"}]},"a/b":true,"c":{"d":2}}`), &doc))
+
+	t.Run("should visit all values in order", func(t *testing.T) {
+		var pointers []string
+		require.NoError(t, Walk(doc, func(pointer string, value any) error {
+			pointers = append(pointers, pointer)
+
+			expected, err := Get(doc, pointer)
+			require.NoError(t, err)
+			assert.Equal(t, expected, value)
+
+			return nil
+		}))
+
+		assert.Equal(t, []string{"", "/z", "/z/y", "/z/y/0", "/z/y/1", "/z/y/1/x", "/a~1b", "/c", "/c/d"}, pointers)
+	})
+
+	t.Run("should visit maps sorted by key", func(t *testing.T) {
+		var pointers []string
+		require.NoError(t, Walk(map[string]any{"b": 1, "a": []any{2}}, func(pointer string, _ any) error {
+			pointers = append(pointers, pointer)
+
+			return nil
+		}))
+
+		assert.Equal(t, []string{"", "/a", "/a/0", "/b"}, pointers)
+	})
+
+	t.Run("should skip children", func(t *testing.T) {
+		var pointers []string
+		require.NoError(t, Walk(doc, func(pointer string, _ any) error {
+			pointers = append(pointers, pointer)
+			if pointer == "/z" {
+				return ErrSkipChildren
+			}
+
+			return nil
+		}))
+
+		assert.Equal(t, []string{"", "/z", "/a~1b", "/c", "/c/d"}, pointers)
+	})
+
+	t.Run("should stop on error", func(t *testing.T) {
+		errStop := errors.New("stop")
+		var pointers []string
+		err := Walk(doc, func(pointer string, _ any) error {
+			pointers = append(pointers, pointer)
+			if pointer == "/z/y/0" {
+				return errStop
+			}
+
+			return nil
+		})
+
+		require.ErrorIs(t, err, errStop)
+		assert.Equal(t, []string{"", "/z", "/z/y", "/z/y/0"}, pointers)
+	})
+}
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package loading
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores remote documents, keyed by URL.
+//
+// A cache is enabled for the remote loader with [WithCache].
+//
+// Implementations must be safe for concurrent use.
+// This package provides an in-memory implementation ([MemoryCache]) and an on-disk one ([DiskCache]).
+type Cache interface {
+	// Get returns the entry stored for key, if any.
+	Get(key string) (CacheEntry, bool)
+
+	// Set stores an entry for key, replacing any previous entry.
+	Set(key string, entry CacheEntry)
+}
+
+// CacheEntry is a remote document held by a [Cache], with the validators needed to revalidate it.
+type CacheEntry struct {
+	// Data is the content of the document
+	Data []byte `json:"data"`
+
+	// ETag is the value of the ETag header returned by the server, if any
+	ETag string `json:"etag,omitempty"`
+
+	// LastModified is the value of the Last-Modified header returned by the server, if any
+	LastModified string `json:"lastModified,omitempty"`
+
+	// ContentType is the value of the Content-Type header returned by the server, if any
+	ContentType string `json:"contentType,omitempty"`
+
+	// Expires is the time after which the entry must be revalidated.
+	//
+	// It is derived from the max-age directive of the Cache-Control header.
+	// A zero value means that the entry must always be revalidated.
+	Expires time.Time `json:"expires,omitzero"`
+}
+
+// IsFresh reports whether the entry may be served without revalidation with the server.
+func (e CacheEntry) IsFresh(now time.Time) bool {
+	return !e.Expires.IsZero() && now.Before(e.Expires)
+}
+
+// cacheEntryFromResponse builds a cache entry from the headers of a response.
+//
+// It returns false whenever the response must not be stored, i.e. if Cache-Control tells so,
+// or if the entry would neither be fresh nor carry any validator.
+func cacheEntryFromResponse(resp *http.Response, data []byte, now time.Time) (CacheEntry, bool) {
+	entry := CacheEntry{
+		Data:         data,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		ContentType:  resp.Header.Get("Content-Type"),
+	}
+
+	maxAge, noStore := parseCacheControl(resp.Header.Get("Cache-Control"))
+	if noStore {
+		return entry, false
+	}
+
+	if maxAge > 0 {
+		entry.Expires = now.Add(maxAge)
+	}
+
+	return entry, entry.ETag != "" || entry.LastModified != "" || !entry.Expires.IsZero()
+}
+
+// parseCacheControl extracts the max-age and no-store directives from a Cache-Control header.
+//
+// The no-cache directive yields a zero max-age, so the entry is always revalidated.
+func parseCacheControl(header string) (maxAge time.Duration, noStore bool) {
+	var noCache bool
+
+	for directive := range strings.SplitSeq(header, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+
+		switch strings.ToLower(name) {
+		case "no-store":
+			noStore = true
+		case "no-cache":
+			noCache = true
+		case "max-age":
+			seconds, err := strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+			if err == nil && seconds > 0 {
+				maxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	if noCache {
+		maxAge = 0
+	}
+
+	return maxAge, noStore
+}
+
+var _ Cache = &MemoryCache{}
+
+// MemoryCache is an in-memory [Cache].
+//
+// Entries are kept for the lifetime of the cache. Use [MemoryCache.Reset] to clear it.
+//
+// The zero value is an empty cache ready to use.
+type MemoryCache struct {
+	mx      sync.RWMutex
+	entries map[string]CacheEntry
+}
+
+// NewMemoryCache builds a new, empty in-memory [Cache].
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]CacheEntry),
+	}
+}
+
+// Get returns the entry stored for key, if any.
+func (c *MemoryCache) Get(key string) (CacheEntry, bool) {
+	c.mx.RLock()
+	entry, ok := c.entries[key]
+	c.mx.RUnlock()
+
+	entry.Data = slices.Clone(entry.Data)
+
+	return entry, ok
+}
+
+// Set stores an entry for key.
+func (c *MemoryCache) Set(key string, entry CacheEntry) {
+	entry.Data = slices.Clone(entry.Data)
+
+	c.mx.Lock()
+	if c.entries == nil {
+		c.entries = make(map[string]CacheEntry)
+	}
+	c.entries[key] = entry
+	c.mx.Unlock()
+}
+
+// Reset removes all entries from the cache.
+func (c *MemoryCache) Reset() {
+	c.mx.Lock()
+	clear(c.entries)
+	c.mx.Unlock()
+}
+
+var _ Cache = DiskCache{}
+
+// DiskCache is a [Cache] that stores entries as files in a directory.
+//
+// Each entry is stored in a file named after the SHA-256 hash of its key.
+// Failures to read or write the cache are not reported: they result in a cache miss.
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache builds an on-disk [Cache] stored in dir.
+//
+// The directory is created on the first write if it does not exist.
+func NewDiskCache(dir string) DiskCache {
+	return DiskCache{dir: dir}
+}
+
+// Get returns the entry stored for key, if any.
+func (c DiskCache) Get(key string) (CacheEntry, bool) {
+	var entry CacheEntry
+
+	data, err := os.ReadFile(c.fileName(key))
+	if err != nil {
+		return entry, false
+	}
+
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return entry, false
+	}
+
+	return entry, true
+}
+
+// Set stores an entry for key.
+//
+// The file is written atomically, so that concurrent readers never see a partially written entry.
+func (c DiskCache) Set(key string, entry CacheEntry) {
+	const dirPerm = 0o700
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(c.dir, dirPerm); err != nil {
+		return
+	}
+
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return
+	}
+	tmpName := tmp.Name()
+
+	_, err = tmp.Write(data)
+	if e := tmp.Close(); err == nil {
+		err = e
+	}
+	if err == nil {
+		err = os.Rename(tmpName, c.fileName(key))
+	}
+
+	if err != nil {
+		_ = os.Remove(tmpName)
+	}
+}
+
+func (c DiskCache) fileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package loading
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestWithCache(t *testing.T) {
+	const (
+		content = "the content"
+		etag    = `"v1"`
+		lastMod = "Mon, 02 Jan 2006 15:04:05 GMT"
+	)
+
+	for name, newCache := range map[string]func(*testing.T) Cache{
+		"memory": func(*testing.T) Cache { return NewMemoryCache() },
+		"disk":   func(t *testing.T) Cache { return NewDiskCache(t.TempDir()) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Run("should serve a fresh document from the cache", func(t *testing.T) {
+				var hits atomic.Int32
+				ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+					hits.Add(1)
+					rw.Header().Set("Cache-Control", "public, max-age=60")
+					_, _ = rw.Write([]byte(content))
+				}))
+				defer ts.Close()
+
+				cache := newCache(t)
+				for range 3 {
+					b, err := LoadFromFileOrHTTP(ts.URL, WithCache(cache))
+					require.NoError(t, err)
+					assert.EqualT(t, content, string(b))
+				}
+				assert.EqualT(t, int32(1), hits.Load())
+			})
+
+			t.Run("should revalidate a document with its ETag", func(t *testing.T) {
+				var hits, notModified atomic.Int32
+				ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+					hits.Add(1)
+					rw.Header().Set("Cache-Control", "no-cache")
+					rw.Header().Set("ETag", etag)
+					if r.Header.Get("If-None-Match") == etag {
+						notModified.Add(1)
+						rw.WriteHeader(http.StatusNotModified)
+
+						return
+					}
+					_, _ = rw.Write([]byte(content))
+				}))
+				defer ts.Close()
+
+				cache := newCache(t)
+				for range 3 {
+					b, err := LoadFromFileOrHTTP(ts.URL, WithCache(cache))
+					require.NoError(t, err)
+					assert.EqualT(t, content, string(b))
+				}
+				assert.EqualT(t, int32(3), hits.Load())
+				assert.EqualT(t, int32(2), notModified.Load())
+			})
+
+			t.Run("should revalidate a document with its modification date", func(t *testing.T) {
+				var notModified atomic.Int32
+				ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+					if r.Header.Get("If-Modified-Since") == lastMod {
+						notModified.Add(1)
+						rw.WriteHeader(http.StatusNotModified)
+
+						return
+					}
+					rw.Header().Set("Last-Modified", lastMod)
+					_, _ = rw.Write([]byte(content))
+				}))
+				defer ts.Close()
+
+				cache := newCache(t)
+				for range 2 {
+					b, err := LoadFromFileOrHTTP(ts.URL, WithCache(cache))
+					require.NoError(t, err)
+					assert.EqualT(t, content, string(b))
+				}
+				assert.EqualT(t, int32(1), notModified.Load())
+			})
+
+			t.Run("should not store a document when told so", func(t *testing.T) {
+				var hits atomic.Int32
+				ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+					hits.Add(1)
+					rw.Header().Set("Cache-Control", "no-store, max-age=60")
+					rw.Header().Set("ETag", etag)
+					_, _ = rw.Write([]byte(content))
+				}))
+				defer ts.Close()
+
+				cache := newCache(t)
+				for range 2 {
+					_, err := LoadFromFileOrHTTP(ts.URL, WithCache(cache))
+					require.NoError(t, err)
+				}
+				assert.EqualT(t, int32(2), hits.Load())
+
+				_, isCached := cache.Get(ts.URL)
+				assert.FalseT(t, isCached)
+			})
+
+			t.Run("should not store a failed response", func(t *testing.T) {
+				ts := httptest.NewServer(http.HandlerFunc(serveKO))
+				defer ts.Close()
+
+				cache := newCache(t)
+				_, err := LoadFromFileOrHTTP(ts.URL, WithCache(cache))
+				require.Error(t, err)
+
+				_, isCached := cache.Get(ts.URL)
+				assert.FalseT(t, isCached)
+			})
+		})
+	}
+}
+
+func TestCacheEntry(t *testing.T) {
+	now := time.Now()
+
+	t.Run("should be fresh before expiry", func(t *testing.T) {
+		assert.TrueT(t, CacheEntry{Expires: now.Add(time.Minute)}.IsFresh(now))
+		assert.FalseT(t, CacheEntry{Expires: now.Add(-time.Minute)}.IsFresh(now))
+		assert.FalseT(t, CacheEntry{}.IsFresh(now))
+	})
+
+	t.Run("should parse Cache-Control directives", func(t *testing.T) {
+		for _, tc := range []struct {
+			Header  string
+			MaxAge  time.Duration
+			NoStore bool
+		}{
+			{Header: ""},
+			{Header: "max-age=60", MaxAge: time.Minute},
+			{Header: `public, MAX-AGE="120"`, MaxAge: 2 * time.Minute},
+			{Header: "max-age=60, no-cache"},
+			{Header: "max-age=invalid"},
+			{Header: "no-store", NoStore: true},
+		} {
+			t.Run(tc.Header, func(t *testing.T) {
+				maxAge, noStore := parseCacheControl(tc.Header)
+				assert.EqualT(t, tc.MaxAge, maxAge)
+				assert.EqualT(t, tc.NoStore, noStore)
+			})
+		}
+	})
+}
+
+func TestMemoryCache(t *testing.T) {
+	cache := NewMemoryCache()
+	data := []byte("content")
+	cache.Set("key", CacheEntry{Data: data})
+
+	t.Run("should not share data with the caller", func(t *testing.T) {
+		data[0] = 'X'
+
+		entry, ok := cache.Get("key")
+		require.TrueT(t, ok)
+		assert.EqualT(t, "content", string(entry.Data))
+
+		entry.Data[0] = 'Y'
+		again, ok := cache.Get("key")
+		require.TrueT(t, ok)
+		assert.EqualT(t, "content", string(again.Data))
+	})
+
+	t.Run("should reset the cache", func(t *testing.T) {
+		cache.Reset()
+		_, ok := cache.Get("key")
+		assert.FalseT(t, ok)
+	})
+
+	t.Run("should use the zero value", func(t *testing.T) {
+		var zero MemoryCache
+
+		_, ok := zero.Get("key")
+		assert.FalseT(t, ok)
+
+		zero.Set("key", CacheEntry{Data: []byte("content")})
+		entry, ok := zero.Get("key")
+		require.TrueT(t, ok)
+		assert.EqualT(t, "content", string(entry.Data))
+	})
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	t.Run("should persist entries across cache instances", func(t *testing.T) {
+		NewDiskCache(dir).Set("key", CacheEntry{Data: []byte("content"), ETag: `"v1"`, Expires: expires})
+
+		entry, ok := NewDiskCache(dir).Get("key")
+		require.TrueT(t, ok)
+		assert.EqualT(t, "content", string(entry.Data))
+		assert.EqualT(t, `"v1"`, entry.ETag)
+		assert.TrueT(t, expires.Equal(entry.Expires))
+	})
+
+	t.Run("should miss an unknown key", func(t *testing.T) {
+		_, ok := NewDiskCache(dir).Get("unknown")
+		assert.FalseT(t, ok)
+	})
+
+	t.Run("should silently miss when the cache cannot be written", func(t *testing.T) {
+		notADir := filepath.Join(dir, "file")
+		require.NoError(t, os.WriteFile(notADir, []byte("content"), 0o600))
+
+		cache := NewDiskCache(notADir)
+		cache.Set("key", CacheEntry{Data: []byte("content")})
+		_, ok := cache.Get("key")
+		assert.FalseT(t, ok)
+	})
+}
@@ -3,6 +3,9 @@
 
 // Package loading provides tools to load a file from http or from a local file system.
 //
+// Documents addressed by URIs with other schemes may be loaded by registering
+// a loader with [WithSchemeLoader].
+//
 // # Security
 //
 // By default, the local loader reads any path the process can access, including absolute
@@ -21,9 +24,10 @@
 // A caller-controlled URL may therefore reach internal services or cloud metadata endpoints
 // (server-side request forgery).
 //
-// This package does not, and should not, embed a network policy:
-// when the URL may derive from untrusted input, supply a restricted client with
-// [WithHTTPClient] whose transport rejects unwanted destinations at dial time — which also
-// covers redirects and DNS rebinding.
-// See the example on [LoadFromFileOrHTTP].
+// When the URL may derive from untrusted input, restrict the destinations with [WithRemotePolicy]:
+// it checks allowed hosts and networks, denies private addresses at dial time — which also
+// covers redirects and DNS rebinding — and caps redirects.
+//
+// Alternatively, supply a restricted client with [WithHTTPClient] whose transport rejects unwanted
+// destinations at dial time. See the example on [LoadFromFileOrHTTP].
 package loading
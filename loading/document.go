@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package loading
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/url"
+	"strings"
+
+	"github.com/go-openapi/swag/yamlutils"
+)
+
+// Format is the format of a document, as detected by [Doc].
+type Format uint8
+
+const (
+	// FormatUnknown is the zero value of a [Format]
+	FormatUnknown Format = iota
+
+	// FormatJSON is a JSON document
+	FormatJSON
+
+	// FormatYAML is a YAML document
+	FormatYAML
+)
+
+func (f Format) String() string {
+	switch f {
+	case FormatJSON:
+		return "json"
+	case FormatYAML:
+		return "yaml"
+	default:
+		return "unknown"
+	}
+}
+
+// Detection tells how the [Format] of a document has been detected by [Doc].
+type Detection uint8
+
+const (
+	// DetectedByContentType means that the format is given by the Content-Type of a remote document
+	DetectedByContentType Detection = iota + 1
+
+	// DetectedByExtension means that the format is given by the extension of the path of the document
+	DetectedByExtension
+
+	// DetectedByContent means that the format is inferred from the content of the document
+	DetectedByContent
+)
+
+func (d Detection) String() string {
+	switch d {
+	case DetectedByContentType:
+		return "content-type"
+	case DetectedByExtension:
+		return "extension"
+	case DetectedByContent:
+		return "content"
+	default:
+		return "unknown"
+	}
+}
+
+// DocInfo describes a document loaded by [Doc].
+type DocInfo struct {
+	// Format is the format of the original document
+	Format Format
+
+	// DetectedBy tells how the format has been detected
+	DetectedBy Detection
+
+	// ContentType is the Content-Type of a remote document, if any
+	ContentType string
+}
+
+// Doc loads a JSON or YAML document from either a file or a remote url, and converts it to JSON.
+//
+// The format of the document is detected automatically, from the first conclusive of:
+//   - the Content-Type of a remote document (e.g. "application/json", "application/yaml")
+//   - the extension of the path (see [JSONMatcher] and [YAMLMatcher]), ignoring any query or fragment
+//   - the first non-blank character of the content: a document starting with '{' or '[' that is
+//     valid JSON is considered JSON, and any other document is considered YAML
+//
+// Doc returns the document as compact JSON, with the information about the detected format.
+func Doc(path string, opts ...Option) (json.RawMessage, DocInfo, error) {
+	return DocContext(context.Background(), path, opts...)
+}
+
+// DocContext loads a JSON or YAML document and converts it to JSON, with a caller-provided context.
+//
+// See [Doc] and [LoadFromFileOrHTTPContext].
+func DocContext(ctx context.Context, path string, opts ...Option) (json.RawMessage, DocInfo, error) {
+	var info DocInfo
+
+	opts = append(opts[:len(opts):len(opts)], func(o *options) {
+		o.onContentType = func(contentType string) {
+			info.ContentType = contentType
+		}
+	})
+
+	data, err := LoadFromFileOrHTTPContext(ctx, path, opts...)
+	if err != nil {
+		return nil, info, err
+	}
+
+	info.Format, info.DetectedBy = detectFormat(path, info.ContentType, data)
+
+	doc, err := toJSON(data, info.Format)
+	if err != nil {
+		return nil, info, errors.Join(err, ErrLoader)
+	}
+
+	return doc, info, nil
+}
+
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+func toJSON(data []byte, format Format) (json.RawMessage, error) {
+	if format == FormatJSON {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, bytes.TrimPrefix(data, utf8BOM)); err != nil {
+			return nil, err
+		}
+
+		return json.RawMessage(buf.Bytes()), nil
+	}
+
+	yamlDoc, err := yamlutils.BytesToYAMLDoc(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return yamlutils.YAMLToJSON(yamlDoc)
+}
+
+func detectFormat(path, contentType string, data []byte) (Format, Detection) {
+	if format := formatFromContentType(contentType); format != FormatUnknown {
+		return format, DetectedByContentType
+	}
+
+	if format := formatFromExtension(path); format != FormatUnknown {
+		return format, DetectedByExtension
+	}
+
+	return formatFromContent(data), DetectedByContent
+}
+
+func formatFromContentType(contentType string) Format {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return FormatUnknown
+	}
+
+	switch {
+	case mediaType == "application/json", mediaType == "text/json", strings.HasSuffix(mediaType, "+json"):
+		return FormatJSON
+	case mediaType == "application/yaml", mediaType == "application/x-yaml",
+		mediaType == "text/yaml", mediaType == "text/x-yaml", strings.HasSuffix(mediaType, "+yaml"):
+		return FormatYAML
+	default:
+		return FormatUnknown
+	}
+}
+
+func formatFromExtension(path string) Format {
+	if u, err := url.Parse(path); err == nil && u.Scheme != "" && u.Opaque == "" {
+		// ignore the query and the fragment of a URI
+		path = u.Path
+	}
+
+	switch {
+	case JSONMatcher(path):
+		return FormatJSON
+	case YAMLMatcher(path):
+		return FormatYAML
+	default:
+		return FormatUnknown
+	}
+}
+
+func formatFromContent(data []byte) Format {
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(data, utf8BOM), " \t\r\n")
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed) {
+		return FormatJSON
+	}
+
+	// YAML is a superset of JSON: any other content is parsed as YAML
+	return FormatYAML
+}
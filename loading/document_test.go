@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package loading
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestDoc(t *testing.T) {
+	const (
+		jsonContent = `{ "a": 1, "b": [true, "x"] }`
+		yamlContent = "a: 1\nb:\n  - true\n  - x\n"
+		expected    = `{"a":1,"b":[true,"x"]}`
+	)
+
+	serveContent := func(content, contentType string) http.HandlerFunc {
+		return func(rw http.ResponseWriter, _ *http.Request) {
+			rw.Header()["Content-Type"] = []string{contentType}
+			rw.Header().Set("Cache-Control", "max-age=60")
+			_, _ = rw.Write([]byte(content))
+		}
+	}
+
+	t.Run("should detect the format of remote documents", func(t *testing.T) {
+		for _, tc := range []struct {
+			Title       string
+			Content     string
+			ContentType string
+			Path        string
+			Format      Format
+			DetectedBy  Detection
+		}{
+			{Title: "JSON by content type", Content: jsonContent, ContentType: "application/json; charset=utf-8", Path: "/spec.yaml", Format: FormatJSON, DetectedBy: DetectedByContentType},
+			{Title: "JSON by vendor content type", Content: jsonContent, ContentType: "application/vnd.oai.openapi+json", Path: "/spec", Format: FormatJSON, DetectedBy: DetectedByContentType},
+			{Title: "YAML by content type", Content: yamlContent, ContentType: "application/x-yaml", Path: "/spec.json", Format: FormatYAML, DetectedBy: DetectedByContentType},
+			{Title: "JSON by extension", Content: jsonContent, ContentType: "text/plain", Path: "/spec.json?version=2", Format: FormatJSON, DetectedBy: DetectedByExtension},
+			{Title: "YAML by extension", Content: yamlContent, ContentType: "application/octet-stream", Path: "/spec.yml#/definitions", Format: FormatYAML, DetectedBy: DetectedByExtension},
+			{Title: "JSON by content", Content: "\n  " + jsonContent, ContentType: "", Path: "/api/spec", Format: FormatJSON, DetectedBy: DetectedByContent},
+			{Title: "YAML by content", Content: yamlContent, ContentType: "", Path: "/api/spec?format=yaml", Format: FormatYAML, DetectedBy: DetectedByContent},
+		} {
+			t.Run(tc.Title, func(t *testing.T) {
+				ts := httptest.NewServer(serveContent(tc.Content, tc.ContentType))
+				defer ts.Close()
+
+				doc, info, err := Doc(ts.URL + tc.Path)
+				require.NoError(t, err)
+				assert.EqualT(t, expected, string(doc))
+				assert.EqualT(t, tc.Format, info.Format)
+				assert.EqualT(t, tc.DetectedBy, info.DetectedBy)
+				assert.EqualT(t, tc.ContentType, info.ContentType)
+			})
+		}
+	})
+
+	t.Run("should report the content type of a cached document", func(t *testing.T) {
+		ts := httptest.NewServer(serveContent(yamlContent, "application/yaml"))
+		defer ts.Close()
+
+		cache := NewMemoryCache()
+		for range 2 {
+			_, info, err := Doc(ts.URL, WithCache(cache))
+			require.NoError(t, err)
+			assert.EqualT(t, "application/yaml", info.ContentType)
+			assert.EqualT(t, DetectedByContentType, info.DetectedBy)
+		}
+	})
+
+	t.Run("should detect the format of local documents", func(t *testing.T) {
+		mapfs := fstest.MapFS{
+			"spec.json": &fstest.MapFile{Data: []byte(jsonContent)},
+			"spec.yaml": &fstest.MapFile{Data: []byte(yamlContent)},
+			"spec":      &fstest.MapFile{Data: []byte("\xef\xbb\xbf" + jsonContent)},
+			"flow":      &fstest.MapFile{Data: []byte(`{a: 1, b: [true, x]}`)},
+		}
+
+		for _, tc := range []struct {
+			Path       string
+			Format     Format
+			DetectedBy Detection
+		}{
+			{Path: "spec.json", Format: FormatJSON, DetectedBy: DetectedByExtension},
+			{Path: "spec.yaml", Format: FormatYAML, DetectedBy: DetectedByExtension},
+			{Path: "spec", Format: FormatJSON, DetectedBy: DetectedByContent},
+			{Path: "flow", Format: FormatYAML, DetectedBy: DetectedByContent},
+		} {
+			t.Run(tc.Path, func(t *testing.T) {
+				doc, info, err := Doc(tc.Path, WithFS(mapfs))
+				require.NoError(t, err)
+				assert.EqualT(t, expected, string(doc))
+				assert.EqualT(t, tc.Format, info.Format)
+				assert.EqualT(t, tc.DetectedBy, info.DetectedBy)
+				assert.Empty(t, info.ContentType)
+			})
+		}
+	})
+
+	t.Run("should fail on invalid documents", func(t *testing.T) {
+		mapfs := fstest.MapFS{
+			"invalid.json": &fstest.MapFile{Data: []byte(`{"a":`)},
+			"invalid.yaml": &fstest.MapFile{Data: []byte("a: [")},
+		}
+
+		for _, pth := range []string{"invalid.json", "invalid.yaml", "missing.json"} {
+			t.Run(pth, func(t *testing.T) {
+				_, _, err := Doc(pth, WithFS(mapfs))
+				require.Error(t, err)
+				require.ErrorIs(t, err, ErrLoader)
+			})
+		}
+	})
+
+	t.Run("should honor the context", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			time.Sleep(30 * time.Millisecond)
+			_, _ = rw.Write([]byte(jsonContent))
+		}))
+		defer ts.Close()
+
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+		defer cancel()
+
+		_, _, err := DocContext(ctx, ts.URL)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestFormat(t *testing.T) {
+	assert.EqualT(t, "json", FormatJSON.String())
+	assert.EqualT(t, "yaml", FormatYAML.String())
+	assert.EqualT(t, "unknown", FormatUnknown.String())
+	assert.EqualT(t, "content-type", DetectedByContentType.String())
+	assert.EqualT(t, "extension", DetectedByExtension.String())
+	assert.EqualT(t, "content", DetectedByContent.String())
+	assert.EqualT(t, "unknown", Detection(0).String())
+}
@@ -3,13 +3,151 @@
 
 package loading
 
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
 type loadingError string
 
 const (
 	// ErrLoader is an error raised by the file loader utility
 	ErrLoader loadingError = "loader error"
+
+	// ErrRemotePolicy is an error raised when a remote destination is denied by a [RemotePolicy]
+	ErrRemotePolicy loadingError = "remote destination not allowed"
 )
 
 func (e loadingError) Error() string {
 	return string(e)
 }
+
+// MaxBytesError is raised when a document exceeds the size limit set by [WithMaxBytes].
+//
+// It wraps [ErrLoader].
+type MaxBytesError struct {
+	// Path is the path or URL of the document
+	Path string
+
+	// Limit is the maximum size in bytes of a document
+	Limit int64
+}
+
+func (e *MaxBytesError) Error() string {
+	return fmt.Sprintf("document at %q exceeds the maximum size of %d bytes: %v", e.Path, e.Limit, ErrLoader)
+}
+
+func (e *MaxBytesError) Unwrap() error {
+	return ErrLoader
+}
+
+// ContentTypeError is raised when a remote document is served with a content type
+// that is not allowed by [WithAllowedContentTypes].
+//
+// It wraps [ErrLoader].
+type ContentTypeError struct {
+	// Path is the URL of the document
+	Path string
+
+	// ContentType is the value of the Content-Type header served with the document
+	ContentType string
+}
+
+func (e *ContentTypeError) Error() string {
+	return fmt.Sprintf("document at %q has a content type that is not allowed: %q: %v", e.Path, e.ContentType, ErrLoader)
+}
+
+func (e *ContentTypeError) Unwrap() error {
+	return ErrLoader
+}
+
+// LoadError describes a failure to load a document.
+//
+// It wraps both the underlying cause and [ErrLoader], so callers may use [errors.Is] and [errors.As]
+// to inspect it, e.g. to check for [context.DeadlineExceeded], [fs.ErrNotExist] or a [MaxBytesError].
+type LoadError struct {
+	// Source is the path or URI of the document, as passed to the loader
+	Source string
+
+	// Location is the local path, or the URL of the document, as resolved by the loader.
+	//
+	// For a remote document, this is the URL of the last request, after redirects.
+	Location string
+
+	// StatusCode is the HTTP status code of the last response received from the server.
+	// It is zero for local documents, or when no response has been received.
+	StatusCode int
+
+	// Header holds the headers of the last response received from the server, if any
+	Header http.Header
+
+	// Attempts is the number of HTTP requests sent to the server. It is zero for local documents.
+	Attempts int
+
+	// Err is the underlying cause. It may be nil, e.g. when the server responds with an unexpected status code.
+	Err error
+}
+
+func (e *LoadError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "could not access document at %q", e.Location)
+	if e.Source != e.Location {
+		fmt.Fprintf(&b, " (from %q)", e.Source)
+	}
+
+	if e.StatusCode != 0 {
+		fmt.Fprintf(&b, " [%d %s]", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+
+	if e.Attempts > 1 {
+		fmt.Fprintf(&b, " after %d attempts", e.Attempts)
+	}
+
+	if e.Err != nil {
+		b.WriteString(": ")
+		b.WriteString(e.Err.Error())
+	}
+
+	return b.String()
+}
+
+func (e *LoadError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{ErrLoader}
+	}
+
+	return []error{e.Err, ErrLoader}
+}
+
+// errUnexpectedStatus reports a response with a status code other than 200.
+//
+// It is not exposed: the status code is carried by the [LoadError].
+var errUnexpectedStatus = errors.New("unexpected status code")
+
+// remoteLoadError builds the [LoadError] returned by the remote loader.
+//
+// resp is the last response received from the server, if any.
+func remoteLoadError(path string, attempts int, resp *http.Response, err error) *LoadError {
+	e := &LoadError{
+		Source:   path,
+		Location: path,
+		Attempts: attempts,
+	}
+
+	if resp != nil {
+		e.StatusCode = resp.StatusCode
+		e.Header = resp.Header
+		if resp.Request != nil && resp.Request.URL != nil {
+			e.Location = resp.Request.URL.String()
+		}
+	}
+
+	if !errors.Is(err, errUnexpectedStatus) {
+		e.Err = err
+	}
+
+	return e
+}
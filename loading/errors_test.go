@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package loading
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestLoadError(t *testing.T) {
+	t.Run("with remote documents", func(t *testing.T) {
+		t.Run("should report the status code and headers", func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+				rw.Header().Set("X-Request-Id", "42")
+				rw.WriteHeader(http.StatusNotFound)
+			}))
+			defer ts.Close()
+
+			_, err := LoadFromFileOrHTTP(ts.URL)
+			require.Error(t, err)
+			require.ErrorIs(t, err, ErrLoader)
+
+			var loadErr *LoadError
+			require.ErrorAs(t, err, &loadErr)
+			assert.EqualT(t, ts.URL, loadErr.Source)
+			assert.EqualT(t, ts.URL, loadErr.Location)
+			assert.EqualT(t, http.StatusNotFound, loadErr.StatusCode)
+			assert.EqualT(t, "42", loadErr.Header.Get("X-Request-Id"))
+			assert.EqualT(t, 1, loadErr.Attempts)
+			require.NoError(t, loadErr.Err)
+			assert.EqualT(t, `could not access document at "`+ts.URL+`" [404 Not Found]`, loadErr.Error())
+		})
+
+		t.Run("should report the number of attempts", func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+				rw.WriteHeader(http.StatusServiceUnavailable)
+			}))
+			defer ts.Close()
+
+			_, err := LoadFromFileOrHTTP(ts.URL, WithRetry(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+
+			var loadErr *LoadError
+			require.ErrorAs(t, err, &loadErr)
+			assert.EqualT(t, http.StatusServiceUnavailable, loadErr.StatusCode)
+			assert.EqualT(t, 3, loadErr.Attempts)
+			assert.StringContainsT(t, loadErr.Error(), "after 3 attempts")
+		})
+
+		t.Run("should report the location after redirects", func(t *testing.T) {
+			target := httptest.NewServer(http.HandlerFunc(serveKO))
+			defer target.Close()
+			redirector := httptest.NewServer(http.RedirectHandler(target.URL+"/spec", http.StatusFound))
+			defer redirector.Close()
+
+			_, err := LoadFromFileOrHTTP(redirector.URL)
+
+			var loadErr *LoadError
+			require.ErrorAs(t, err, &loadErr)
+			assert.EqualT(t, redirector.URL, loadErr.Source)
+			assert.EqualT(t, target.URL+"/spec", loadErr.Location)
+		})
+
+		t.Run("should report the cause", func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(serveOK))
+			defer ts.Close()
+
+			ctx, cancel := context.WithCancel(t.Context())
+			cancel()
+
+			_, err := LoadFromFileOrHTTPContext(ctx, ts.URL)
+
+			var loadErr *LoadError
+			require.ErrorAs(t, err, &loadErr)
+			require.ErrorIs(t, loadErr.Err, context.Canceled)
+			assert.EqualT(t, 0, loadErr.Attempts)
+		})
+
+		t.Run("should report a typed cause", func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(serveOK))
+			defer ts.Close()
+
+			_, err := LoadFromFileOrHTTP(ts.URL, WithMaxBytes(1))
+
+			var loadErr *LoadError
+			require.ErrorAs(t, err, &loadErr)
+			assert.EqualT(t, http.StatusOK, loadErr.StatusCode)
+
+			var maxErr *MaxBytesError
+			require.ErrorAs(t, err, &maxErr)
+		})
+	})
+
+	t.Run("with local documents", func(t *testing.T) {
+		t.Run("should report the resolved path", func(t *testing.T) {
+			dir := t.TempDir()
+			source := "file://" + filepath.ToSlash(filepath.Join(dir, "missing.yaml"))
+
+			_, err := LoadFromFileOrHTTP(source)
+			require.ErrorIs(t, err, ErrLoader)
+			require.ErrorIs(t, err, fs.ErrNotExist)
+
+			var loadErr *LoadError
+			require.ErrorAs(t, err, &loadErr)
+			assert.EqualT(t, source, loadErr.Source)
+			assert.EqualT(t, filepath.Join(dir, "missing.yaml"), loadErr.Location)
+			assert.EqualT(t, 0, loadErr.StatusCode)
+			assert.EqualT(t, 0, loadErr.Attempts)
+		})
+
+		t.Run("should report an invalid path", func(t *testing.T) {
+			_, err := LoadFromFileOrHTTP("/folder%GF/myfile.yaml", WithFS(fstest.MapFS{}))
+
+			var loadErr *LoadError
+			require.ErrorAs(t, err, &loadErr)
+			require.ErrorIs(t, err, ErrLoader)
+		})
+	})
+
+	t.Run("with a registered scheme", func(t *testing.T) {
+		errNotFound := errors.New("not found")
+
+		_, err := LoadFromFileOrHTTP("mem://spec", WithSchemeLoader("mem", func(context.Context, *url.URL) ([]byte, error) {
+			return nil, errNotFound
+		}))
+		require.ErrorIs(t, err, errNotFound)
+		require.ErrorIs(t, err, ErrLoader)
+
+		var loadErr *LoadError
+		require.ErrorAs(t, err, &loadErr)
+		assert.EqualT(t, "mem://spec", loadErr.Location)
+	})
+}
@@ -62,3 +62,25 @@ func ExampleLoadFromFileOrHTTP_restrictNetwork() {
 	// Output:
 	// blocked: true
 }
+
+// ExampleWithRemotePolicy shows how to prevent a caller-controlled URL from reaching
+// loopback, private, or link-local (cloud metadata) addresses.
+//
+// Here a loopback test server stands in for an internal endpoint that the policy must refuse to reach.
+func ExampleWithRemotePolicy() {
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("internal secret"))
+	}))
+	defer internal.Close()
+
+	_, err := loading.LoadFromFileOrHTTP(internal.URL,
+		loading.WithRemotePolicy(loading.RemotePolicy{
+			DenyPrivateNetworks: true,
+			MaxRedirects:        3,
+		}),
+	)
+	fmt.Println("blocked:", errors.Is(err, loading.ErrRemotePolicy))
+
+	// Output:
+	// blocked: true
+}
@@ -4,6 +4,7 @@
 package loading
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"path/filepath"
@@ -17,7 +18,14 @@ func JSONMatcher(path string) bool {
 
 // JSONDoc loads a json document from either a file or a remote url.
 func JSONDoc(path string, opts ...Option) (json.RawMessage, error) {
-	data, err := LoadFromFileOrHTTP(path, opts...)
+	return JSONDocContext(context.Background(), path, opts...)
+}
+
+// JSONDocContext loads a json document from either a file or a remote url, with a caller-provided context.
+//
+// See [LoadFromFileOrHTTPContext].
+func JSONDocContext(ctx context.Context, path string, opts ...Option) (json.RawMessage, error) {
+	data, err := LoadFromFileOrHTTPContext(ctx, path, opts...)
 	if err != nil {
 		return nil, errors.Join(err, ErrLoader)
 	}
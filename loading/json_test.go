@@ -4,6 +4,7 @@
 package loading
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -43,4 +44,16 @@ func TestJSONDoc(t *testing.T) {
 		_, err := JSONDoc(ts.URL)
 		require.Error(t, err)
 	})
+
+	t.Run("should not retrieve any doc with a canceled context", func(t *testing.T) {
+		serv := httptest.NewServer(http.HandlerFunc(serveJSONPetStore))
+		defer serv.Close()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		_, err := JSONDocContext(ctx, serv.URL)
+		require.ErrorIs(t, err, context.Canceled)
+		require.ErrorIs(t, err, ErrLoader)
+	})
 }
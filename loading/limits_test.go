@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package loading
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestWithMaxBytes(t *testing.T) {
+	const (
+		content = "the content"
+		limit   = int64(len(content))
+	)
+	large := strings.Repeat("x", len(content)+1)
+
+	t.Run("with local files", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "small.yaml"), []byte(content), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "large.yaml"), []byte(large), 0o600))
+		mapfs := fstest.MapFS{
+			"small.yaml": &fstest.MapFile{Data: []byte(content)},
+			"large.yaml": &fstest.MapFile{Data: []byte(large)},
+		}
+
+		for name, tc := range map[string]struct {
+			Prefix string
+			Opts   []Option
+		}{
+			"os":      {Prefix: dir + string(filepath.Separator)},
+			"os.Root": {Opts: []Option{WithRoot(dir)}},
+			"fs.FS":   {Opts: []Option{WithFS(mapfs)}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				opts := append([]Option{WithMaxBytes(limit)}, tc.Opts...)
+
+				t.Run("should load a document within the limit", func(t *testing.T) {
+					b, err := LoadFromFileOrHTTP(tc.Prefix+"small.yaml", opts...)
+					require.NoError(t, err)
+					assert.EqualT(t, content, string(b))
+				})
+
+				t.Run("should not load a document beyond the limit", func(t *testing.T) {
+					_, err := LoadFromFileOrHTTP(tc.Prefix+"large.yaml", opts...)
+					require.Error(t, err)
+					require.ErrorIs(t, err, ErrLoader)
+
+					var maxErr *MaxBytesError
+					require.ErrorAs(t, err, &maxErr)
+					assert.EqualT(t, limit, maxErr.Limit)
+				})
+
+				t.Run("should not load a missing document", func(t *testing.T) {
+					_, err := LoadFromFileOrHTTP(tc.Prefix+"missing.yaml", opts...)
+					require.Error(t, err)
+				})
+			})
+		}
+	})
+
+	t.Run("with remote documents", func(t *testing.T) {
+		serveContent := func(body string, flush bool) http.HandlerFunc {
+			return func(rw http.ResponseWriter, _ *http.Request) {
+				if flush {
+					// the size of the document is not announced
+					rw.(http.Flusher).Flush()
+				}
+				_, _ = rw.Write([]byte(body))
+			}
+		}
+
+		for _, flush := range []bool{false, true} {
+			name := "with Content-Length"
+			if flush {
+				name = "without Content-Length"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				t.Run("should load a document within the limit", func(t *testing.T) {
+					ts := httptest.NewServer(serveContent(content, flush))
+					defer ts.Close()
+
+					b, err := LoadFromFileOrHTTP(ts.URL, WithMaxBytes(limit))
+					require.NoError(t, err)
+					assert.EqualT(t, content, string(b))
+				})
+
+				t.Run("should not load a document beyond the limit", func(t *testing.T) {
+					ts := httptest.NewServer(serveContent(large, flush))
+					defer ts.Close()
+
+					_, err := LoadFromFileOrHTTP(ts.URL, WithMaxBytes(limit))
+					require.Error(t, err)
+					require.ErrorIs(t, err, ErrLoader)
+
+					var maxErr *MaxBytesError
+					require.ErrorAs(t, err, &maxErr)
+					assert.EqualT(t, ts.URL, maxErr.Path)
+				})
+			})
+		}
+	})
+}
+
+func TestWithAllowedContentTypes(t *testing.T) {
+	serveContentType := func(contentType string) http.HandlerFunc {
+		return func(rw http.ResponseWriter, _ *http.Request) {
+			rw.Header()["Content-Type"] = []string{contentType}
+			_, _ = rw.Write(yamlPetStore)
+		}
+	}
+
+	for _, tc := range []struct {
+		ContentType string
+		Allowed     []string
+		ExpectError bool
+	}{
+		{ContentType: "text/html", Allowed: nil},
+		{ContentType: "application/yaml", Allowed: []string{"application/json", "application/yaml"}},
+		{ContentType: "Application/YAML; charset=utf-8", Allowed: []string{"application/yaml"}},
+		{ContentType: "application/x-yaml", Allowed: []string{"application/*"}},
+		{ContentType: "text/html; charset=utf-8", Allowed: []string{"application/json", "application/yaml"}, ExpectError: true},
+		{ContentType: "text/html", Allowed: []string{"application/*"}, ExpectError: true},
+		{ContentType: "", Allowed: []string{"application/yaml"}, ExpectError: true},
+		{ContentType: "invalid;;", Allowed: []string{"application/yaml"}, ExpectError: true},
+	} {
+		t.Run(tc.ContentType, func(t *testing.T) {
+			ts := httptest.NewServer(serveContentType(tc.ContentType))
+			defer ts.Close()
+
+			b, err := LoadFromFileOrHTTP(ts.URL, WithAllowedContentTypes(tc.Allowed...))
+			if tc.ExpectError {
+				require.Error(t, err)
+				require.ErrorIs(t, err, ErrLoader)
+
+				var ctErr *ContentTypeError
+				require.ErrorAs(t, err, &ctErr)
+				assert.EqualT(t, tc.ContentType, ctErr.ContentType)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.YAMLEqT(t, string(yamlPetStore), string(b))
+		})
+	}
+}
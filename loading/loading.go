@@ -4,17 +4,20 @@
 package loading
 
 import (
+	"cmp"
 	"context"
 	"embed"
-	"fmt"
+	"errors"
 	"io"
 	"log"
+	"mime"
 	"net/http"
 	"net/url"
 	"path"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 )
 
 // LoadFromFileOrHTTP loads the bytes from a file or a remote http server based on the path passed in.
@@ -22,17 +25,38 @@ import (
 // Security: by default a local path is read with no confinement, so a caller-controlled path
 // (including a "file://" URI or an absolute path) may read any file the process can access.
 // When the path may derive from untrusted input, confine local loading with [WithRoot].
+//
+// Failures to load a document are reported as a [*LoadError], which wraps [ErrLoader].
 func LoadFromFileOrHTTP(pth string, opts ...Option) ([]byte, error) {
+	return LoadFromFileOrHTTPContext(context.Background(), pth, opts...)
+}
+
+// LoadFromFileOrHTTPContext behaves like [LoadFromFileOrHTTP], with a caller-provided context.
+//
+// The context is passed to the remote HTTP request. It is also checked before and after a local read.
+//
+// When the context is canceled or its deadline is exceeded, the returned error wraps both
+// the context error (e.g. [context.Canceled] or [context.DeadlineExceeded]) and [ErrLoader].
+//
+// The timeout set by [WithTimeout] still applies to remote requests, on top of any deadline carried by ctx.
+func LoadFromFileOrHTTPContext(ctx context.Context, pth string, opts ...Option) ([]byte, error) {
 	o := optionsWithDefaults(opts)
-	return LoadStrategy(pth, o.ReadFileFunc(), loadHTTPBytes(opts...), opts...)(pth)
+	return loadStrategy(ctx, pth, loadLocalBytes(ctx, o.readFileFunc()), loadHTTPBytes(ctx, opts...), o)(pth)
 }
 
 // LoadStrategy returns a loader function for a given path or URI.
 //
-// The load strategy returns the remote load for any path starting with `http`.
-// So this works for any URI with a scheme `http` or `https`.
+// The loader is picked according to the scheme of the URI, from a registry of loaders.
+//
+// The built-in registry holds:
+//   - the remote loader, for any URI with a scheme `http` or `https`
+//   - the local loader, for any URI with a scheme `file`
 //
-// The fallback strategy is to call the local loader.
+// Loaders for other schemes (e.g. "data", "mem") may be added with [WithSchemeLoader].
+// Built-in loaders may be overridden the same way.
+//
+// The fallback strategy, for paths without a scheme or with a scheme that is not registered,
+// is to call the local loader.
 //
 // The local loader takes a local file system path (absolute or relative) as argument,
 // or alternatively a `file://...` URI, **without host** (see also below for windows).
@@ -58,10 +82,32 @@ func LoadFromFileOrHTTP(pth string, opts ...Option) ([]byte, error) {
 // - `file:///c:/folder/file` becomes `C:\folder\file`
 // - `file://c:/folder/file` is tolerated (without leading `/`) and becomes `c:\folder\file`
 func LoadStrategy(pth string, local, remote func(string) ([]byte, error), opts ...Option) func(string) ([]byte, error) {
-	if hasHTTPScheme(pth) {
-		return remote
+	return loadStrategy(context.Background(), pth, local, remote, optionsWithDefaults(opts))
+}
+
+func loadStrategy(ctx context.Context, pth string, local, remote func(string) ([]byte, error), o options) func(string) ([]byte, error) {
+	localStrategy := localLoadStrategy(local, o)
+
+	if scheme, ok := uriScheme(pth); ok && o.routesScheme(pth, scheme) {
+		if loader, isRegistered := o.registry(ctx, localStrategy, remote)[scheme]; isRegistered {
+			return loader
+		}
 	}
-	o := optionsWithDefaults(opts)
+
+	return localStrategy
+}
+
+// localLoadStrategy prepares a path or a "file://" URI before it is passed to the local loader.
+func localLoadStrategy(local func(string) ([]byte, error), o options) func(string) ([]byte, error) {
+	load := func(source, location string) ([]byte, error) {
+		data, err := local(location)
+		if err != nil {
+			return nil, &LoadError{Source: source, Location: location, Err: err}
+		}
+
+		return data, nil
+	}
+
 	_, isEmbedFS := o.fs.(embed.FS)
 	// any loader backed by an fs.FS or an os.Root consumes forward-slash paths on every
 	// platform, so it must not go through the windows-native file:// preprocessing below.
@@ -70,7 +116,7 @@ func LoadStrategy(pth string, local, remote func(string) ([]byte, error), opts .
 	return func(p string) ([]byte, error) {
 		upth, err := url.PathUnescape(p)
 		if err != nil {
-			return nil, err
+			return nil, &LoadError{Source: p, Location: p, Err: err}
 		}
 
 		cpth, hasPrefix := strings.CutPrefix(upth, "file://")
@@ -79,7 +125,7 @@ func LoadStrategy(pth string, local, remote func(string) ([]byte, error), opts .
 			// regular file path provided: just normalize slashes
 			if isEmbedFS {
 				// embed.FS always uses "/" as separator, even on windows, and rejects leading "./" or "/".
-				return local(strings.TrimLeft(filepath.ToSlash(cpth), "./")) // remove invalid leading characters for embed FS
+				return load(p, strings.TrimLeft(filepath.ToSlash(cpth), "./")) // remove invalid leading characters for embed FS
 			}
 
 			if isFSBacked {
@@ -87,10 +133,10 @@ func LoadStrategy(pth string, local, remote func(string) ([]byte, error), opts .
 				// Path confinement is enforced by the loader, not here: the os.Root loader rebases
 				// absolute in-root paths and rejects escaping paths ("..", out-of-root absolute,
 				// escaping symlinks); an fs.FS loader rejects what its file system does not allow.
-				return local(filepath.ToSlash(cpth))
+				return load(p, filepath.ToSlash(cpth))
 			}
 
-			return local(filepath.FromSlash(cpth))
+			return load(p, filepath.FromSlash(cpth))
 		}
 
 		// windows-only pre-processing of file://... URIs, excluding embed.FS
@@ -98,7 +144,7 @@ func LoadStrategy(pth string, local, remote func(string) ([]byte, error), opts .
 		// support for canonical file URIs on windows.
 		u, err := url.Parse(filepath.ToSlash(upth))
 		if err != nil {
-			return nil, err
+			return nil, &LoadError{Source: p, Location: p, Err: err}
 		}
 
 		if u.Host != "" {
@@ -124,31 +170,71 @@ func LoadStrategy(pth string, local, remote func(string) ([]byte, error), opts .
 			}
 		}
 
-		return local(filepath.FromSlash(upth))
+		return load(p, filepath.FromSlash(upth))
 	}
 }
 
-// hasHTTPScheme reports whether pth is an absolute URL with an http or https scheme,
-// selecting the remote loader. The comparison is case-insensitive, as URL schemes are.
-//
-// Requiring the "://" separator (rather than a bare "http" prefix) avoids misrouting a
-// local file whose name merely starts with "http" (e.g. "httpbin.json") to the remote loader.
-func hasHTTPScheme(pth string) bool {
-	for _, scheme := range [...]string{"http://", "https://"} {
-		if len(pth) >= len(scheme) && strings.EqualFold(pth[:len(scheme)], scheme) {
-			return true
+// loadLocalBytes wraps a local loader so that it honors the cancellation of ctx.
+func loadLocalBytes(ctx context.Context, local func(string) ([]byte, error)) func(string) ([]byte, error) {
+	return func(pth string) ([]byte, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
 		}
-	}
 
-	return false
+		data, err := local(pth)
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+
+		return data, err
+	}
 }
 
-func loadHTTPBytes(opts ...Option) func(path string) ([]byte, error) {
+func loadHTTPBytes(ctx context.Context, opts ...Option) func(path string) ([]byte, error) {
 	o := optionsWithDefaults(opts)
 
 	return func(path string) ([]byte, error) {
-		client := o.client
-		timeoutCtx := context.Background()
+		if err := ctx.Err(); err != nil {
+			return nil, remoteLoadError(path, 0, nil, err)
+		}
+
+		o := o // the client is guarded for this load only
+		if o.remotePolicy != nil {
+			u, err := url.Parse(path)
+			if err != nil {
+				return nil, remoteLoadError(path, 0, nil, err)
+			}
+
+			if err := o.remotePolicy.checkHost(u.Hostname()); err != nil {
+				return nil, remoteLoadError(path, 0, nil, err)
+			}
+
+			client, err := o.remotePolicy.guardClient(o.client)
+			if err != nil {
+				return nil, remoteLoadError(path, 0, nil, err)
+			}
+			defer client.CloseIdleConnections()
+
+			o.client = client
+		}
+
+		var cached *CacheEntry
+
+		if o.cache != nil {
+			if entry, isCached := o.cache.Get(path); isCached {
+				if entry.IsFresh(time.Now()) {
+					o.reportContentType(entry.ContentType)
+
+					return entry.Data, nil
+				}
+
+				cached = &entry
+			}
+		}
+
+		// the timeout applies to the load as a whole, including all retried attempts
+		timeoutCtx := ctx
 		var cancel func()
 
 		if o.httpTimeout > 0 {
@@ -156,35 +242,157 @@ func loadHTTPBytes(opts ...Option) func(path string) ([]byte, error) {
 			defer cancel()
 		}
 
-		req, err := http.NewRequestWithContext(timeoutCtx, http.MethodGet, path, nil)
+		req, err := o.newRequest(timeoutCtx, path, cached)
 		if err != nil {
-			return nil, err
+			return nil, remoteLoadError(path, 0, nil, err)
 		}
 
-		if o.basicAuthUsername != "" && o.basicAuthPassword != "" {
-			req.SetBasicAuth(o.basicAuthUsername, o.basicAuthPassword)
-		}
+		for attempt := 1; ; attempt++ {
+			data, resp, err := o.fetch(req.Clone(timeoutCtx), path, cached)
+			if err == nil {
+				return data, nil
+			}
 
-		for key, val := range o.customHeaders {
-			req.Header.Set(key, val)
+			delay, retry := o.retry.next(timeoutCtx, attempt, resp, err)
+			if !retry {
+				return nil, remoteLoadError(path, attempt, resp, err)
+			}
+
+			if waitErr := sleepContext(timeoutCtx, delay); waitErr != nil {
+				return nil, remoteLoadError(path, attempt, resp, errors.Join(err, waitErr))
+			}
 		}
+	}
+}
 
-		resp, err := client.Do(req)
-		defer func() {
-			if resp != nil {
-				if e := resp.Body.Close(); e != nil {
-					log.Println(e)
-				}
+// newRequest prepares the request to retrieve a remote document, turning it into
+// a conditional request whenever a cached entry is available.
+func (o options) newRequest(ctx context.Context, path string, cached *CacheEntry) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.basicAuthUsername != "" && o.basicAuthPassword != "" {
+		req.SetBasicAuth(o.basicAuthUsername, o.basicAuthPassword)
+	}
+
+	for key, val := range o.customHeaders {
+		req.Header.Set(key, val)
+	}
+
+	if cached != nil {
+		setConditionalHeaders(req, *cached)
+	}
+
+	return req, nil
+}
+
+// fetch performs a single attempt at retrieving a remote document.
+//
+// The response is returned with its body already closed, so that the caller may inspect its status and headers.
+// It is nil whenever no response was received.
+func (o options) fetch(req *http.Request, path string, cached *CacheEntry) ([]byte, *http.Response, error) {
+	resp, err := o.client.Do(req)
+	defer func() {
+		if resp != nil {
+			if e := resp.Body.Close(); e != nil {
+				log.Println(e)
 			}
-		}()
-		if err != nil {
-			return nil, err
 		}
+	}()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cached != nil && resp.StatusCode == http.StatusNotModified {
+		// the cached document is still valid: refresh its freshness
+		if entry, ok := cacheEntryFromResponse(resp, cached.Data, time.Now()); ok {
+			entry.ETag = cmp.Or(entry.ETag, cached.ETag)
+			entry.LastModified = cmp.Or(entry.LastModified, cached.LastModified)
+			entry.ContentType = cmp.Or(entry.ContentType, cached.ContentType)
+			o.cache.Set(path, entry)
+		}
+		o.reportContentType(cached.ContentType)
+
+		return cached.Data, resp, nil
+	}
 
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("could not access document at %q [%s]: %w", path, resp.Status, ErrLoader)
+	if resp.StatusCode != http.StatusOK {
+		return nil, resp, errUnexpectedStatus
+	}
+
+	if !o.isAllowedContentType(resp.Header.Get("Content-Type")) {
+		return nil, resp, &ContentTypeError{Path: path, ContentType: resp.Header.Get("Content-Type")}
+	}
+
+	data, err := o.readBody(resp, path)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if o.cache != nil {
+		if entry, ok := cacheEntryFromResponse(resp, data, time.Now()); ok {
+			o.cache.Set(path, entry)
 		}
+	}
+	o.reportContentType(resp.Header.Get("Content-Type"))
 
+	return data, resp, nil
+}
+
+func (o options) reportContentType(contentType string) {
+	if o.onContentType != nil {
+		o.onContentType(contentType)
+	}
+}
+
+// readBody reads the body of a response, enforcing the size limit set by [WithMaxBytes], if any.
+func (o options) readBody(resp *http.Response, path string) ([]byte, error) {
+	if o.maxBytes <= 0 {
 		return io.ReadAll(resp.Body)
 	}
+
+	if resp.ContentLength > o.maxBytes {
+		// fail early when the server announces the size of the document
+		return nil, &MaxBytesError{Path: path, Limit: o.maxBytes}
+	}
+
+	return readLimited(resp.Body, path, o.maxBytes)
+}
+
+// isAllowedContentType checks a Content-Type header against the media types set by [WithAllowedContentTypes], if any.
+func (o options) isAllowedContentType(contentType string) bool {
+	if len(o.contentTypes) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range o.contentTypes {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+		if allowed == mediaType {
+			return true
+		}
+
+		if prefix, isWildcard := strings.CutSuffix(allowed, "/*"); isWildcard && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setConditionalHeaders turns a request into a conditional request, using the validators of a cached entry.
+func setConditionalHeaders(req *http.Request, cached CacheEntry) {
+	if cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	if cached.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
 }
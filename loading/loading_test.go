@@ -195,6 +195,69 @@ func TestLoadFromHTTP(t *testing.T) {
 	})
 }
 
+func TestLoadFromFileOrHTTPContext(t *testing.T) {
+	t.Run("should load from remote URL with a context", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(serveOK))
+		defer ts.Close()
+
+		d, err := LoadFromFileOrHTTPContext(t.Context(), ts.URL)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("the content"), d)
+	})
+
+	t.Run("should not load from remote URL when the context is canceled", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(serveOK))
+		defer ts.Close()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		_, err := LoadFromFileOrHTTPContext(ctx, ts.URL)
+		require.Error(t, err)
+		require.ErrorIs(t, err, context.Canceled)
+		require.ErrorIs(t, err, ErrLoader)
+	})
+
+	t.Run("should interrupt a remote request when the context deadline is exceeded", func(t *testing.T) {
+		const (
+			delay = 30 * time.Millisecond
+			wait  = delay / 2
+		)
+
+		serv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			time.Sleep(delay)
+			rw.WriteHeader(http.StatusOK)
+		}))
+		defer serv.Close()
+
+		ctx, cancel := context.WithTimeout(t.Context(), wait)
+		defer cancel()
+
+		_, err := LoadFromFileOrHTTPContext(ctx, serv.URL, WithTimeout(0))
+		require.Error(t, err)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorIs(t, err, ErrLoader)
+	})
+
+	t.Run("should load from local file system with a context", func(t *testing.T) {
+		b, err := LoadFromFileOrHTTPContext(t.Context(), "petstore_fixture.yaml",
+			WithFS(fstest.MapFS{"petstore_fixture.yaml": &fstest.MapFile{Data: yamlPetStore}}),
+		)
+		require.NoError(t, err)
+		assert.YAMLEqT(t, string(yamlPetStore), string(b))
+	})
+
+	t.Run("should not load from local file system when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		_, err := LoadFromFileOrHTTPContext(ctx, "petstore_fixture.yaml", WithFS(embeddedFixtures))
+		require.Error(t, err)
+		require.ErrorIs(t, err, context.Canceled)
+		require.ErrorIs(t, err, ErrLoader)
+	})
+}
+
 func TestLoadStrategy(t *testing.T) {
 	const thisIsNotIt = "not it"
 	loader := func(_ string) ([]byte, error) {
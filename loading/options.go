@@ -5,11 +5,13 @@ package loading
 
 import (
 	"errors"
+	"io"
 	"io/fs"
 	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 )
 
@@ -23,6 +25,17 @@ type (
 		basicAuthPassword string
 		customHeaders     map[string]string
 		client            *http.Client
+		cache             Cache
+		retry             *RetryPolicy
+		contentTypes      []string
+		remotePolicy      *RemotePolicy
+
+		// onContentType is called with the content type of a remote document, once loaded
+		onContentType func(string)
+	}
+
+	uriOptions struct {
+		schemes map[string]SchemeLoader
 	}
 
 	fileOptions struct {
@@ -30,9 +43,15 @@ type (
 		root string // when non-empty, local reads are confined to this directory via os.Root
 	}
 
+	limitOptions struct {
+		maxBytes int64
+	}
+
 	options struct {
 		httpOptions
 		fileOptions
+		uriOptions
+		limitOptions
 	}
 )
 
@@ -66,6 +85,69 @@ func (fo fileOptions) ReadFileFunc() func(string) ([]byte, error) {
 	return fo.fs.ReadFile
 }
 
+// openFileFunc returns a function to open local files, with the same confinement as [fileOptions.ReadFileFunc].
+func (fo fileOptions) openFileFunc() func(string) (fs.File, error) {
+	if fo.root != "" {
+		root := fo.root
+
+		return func(name string) (fs.File, error) {
+			rel, err := rootRelative(root, name)
+			if err != nil {
+				return nil, errors.Join(err, ErrLoader)
+			}
+
+			r, err := os.OpenRoot(root)
+			if err != nil {
+				return nil, errors.Join(err, ErrLoader)
+			}
+			defer func() { _ = r.Close() }()
+
+			return r.Open(rel)
+		}
+	}
+
+	if fo.fs == nil {
+		return func(name string) (fs.File, error) {
+			return os.Open(name)
+		}
+	}
+
+	return fo.fs.Open
+}
+
+// readFileFunc returns the local file loader, enforcing the size limit set by [WithMaxBytes], if any.
+func (o options) readFileFunc() func(string) ([]byte, error) {
+	if o.maxBytes <= 0 {
+		return o.ReadFileFunc()
+	}
+
+	open := o.openFileFunc()
+
+	return func(name string) ([]byte, error) {
+		f, err := open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = f.Close() }()
+
+		return readLimited(f, name, o.maxBytes)
+	}
+}
+
+// readLimited reads at most limit bytes from r, and fails with a [MaxBytesError] if there is more to read.
+func readLimited(r io.Reader, path string, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > limit {
+		return nil, &MaxBytesError{Path: path, Limit: limit}
+	}
+
+	return data, nil
+}
+
 // rootRelative expresses name as a path relative to root, so that it can be resolved by os.Root.
 //
 // A relative name is returned unchanged: os.Root confines it directly (including "../" traversal
@@ -160,6 +242,97 @@ func WithHTTPClient(client *http.Client) Option {
 	}
 }
 
+// WithCache enables a cache for documents fetched by the remote file loader.
+//
+// Cached documents are served without contacting the server as long as they are fresh,
+// according to the max-age directive of the Cache-Control header returned by the server.
+//
+// Other cached documents are revalidated with a conditional request
+// (using the If-None-Match and If-Modified-Since headers): a "304 Not Modified" response
+// is served from the cache.
+//
+// Documents are cached by URL: the cache should not be shared by loaders using different credentials.
+//
+// By default, no cache is used.
+func WithCache(cache Cache) Option {
+	return func(o *options) {
+		o.cache = cache
+	}
+}
+
+// WithRetry enables retries of transient failures for the remote file loader.
+//
+// Fields of the policy that are left unset take their value from [DefaultRetryPolicy].
+//
+// By default, failures are not retried.
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *options) {
+		p := policy.withDefaults()
+		o.retry = &p
+	}
+}
+
+// WithMaxBytes limits the size of the documents loaded from a local file or from a remote server.
+//
+// Loading a larger document fails with a [MaxBytesError], without reading more than maxBytes+1 bytes.
+//
+// The limit does not apply to loaders registered with [WithSchemeLoader].
+//
+// By default, the size of documents is not limited.
+func WithMaxBytes(maxBytes int64) Option {
+	return func(o *options) {
+		o.maxBytes = maxBytes
+	}
+}
+
+// WithAllowedContentTypes restricts the media types accepted for remote documents.
+//
+// A remote document served with a Content-Type header that doesn't match any of the provided
+// media types (e.g. an HTML error page served by a proxy) fails with a [ContentTypeError].
+// A document served without a Content-Type header fails likewise.
+//
+// Media types are matched case-insensitively, ignoring parameters such as "charset".
+// A media type may use a wildcard subtype, e.g. "application/*".
+//
+// By default, all content types are accepted.
+func WithAllowedContentTypes(mediaTypes ...string) Option {
+	return func(o *options) {
+		o.contentTypes = mediaTypes
+	}
+}
+
+// WithRemotePolicy restricts the destinations that the remote file loader may reach.
+//
+// This is the recommended option when loading specs from URLs that may derive from untrusted input.
+// It is the remote counterpart of [WithRoot].
+//
+// By default, any destination may be reached, and redirects are followed like with [http.DefaultClient].
+func WithRemotePolicy(policy RemotePolicy) Option {
+	return func(o *options) {
+		o.remotePolicy = &policy
+	}
+}
+
+// WithSchemeLoader registers a loader for URIs with the given scheme (e.g. "data", "mem").
+//
+// Schemes are case-insensitive. Registering a loader for "http", "https" or "file"
+// overrides the built-in loader for this scheme. The last loader registered for a scheme wins.
+//
+// Unlike with the built-in loaders, the URI doesn't need the "://" separator after the scheme:
+// e.g. with a loader registered for "data", "data:spec.yaml" is a URI rather than a local file.
+//
+// Options that configure the built-in loaders (e.g. [WithHTTPClient], [WithRoot]) have no effect
+// on registered loaders.
+func WithSchemeLoader(scheme string, loader SchemeLoader) Option {
+	return func(o *options) {
+		if o.schemes == nil {
+			o.schemes = make(map[string]SchemeLoader)
+		}
+
+		o.schemes[strings.ToLower(scheme)] = loader
+	}
+}
+
 // WithFS sets a file system for the local file loader.
 //
 // If the provided file system is a [fs.ReadFileFS], the ReadFile function is used.
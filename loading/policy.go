@@ -0,0 +1,196 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package loading
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RemotePolicy restricts the destinations that the remote file loader may reach.
+//
+// It guards against server-side request forgery when the URL of a document derives from untrusted input,
+// e.g. a $ref in a user-supplied spec.
+//
+// Host names are checked against the URL, before the request is sent and on every redirect.
+// Network addresses are checked by the dialer, after DNS resolution and before connecting:
+// this covers redirects and DNS rebinding as well.
+//
+// Network checks require the transport of the HTTP client to be an [*http.Transport] (which is the
+// case of the default client), without a custom TLS dialer: a load with any other transport fails.
+// When network checks are enabled, the dialer of the transport is replaced, and proxies configured on the
+// transport are not used, since the dialer would otherwise check the address of the proxy rather than
+// the address of the destination.
+type RemotePolicy struct {
+	// AllowedHosts lists the host names that may be reached. Host names are matched case-insensitively.
+	//
+	// An entry starting with "*." matches any subdomain, e.g. "*.example.com" matches "api.example.com",
+	// but not "example.com".
+	//
+	// When empty, all host names are allowed.
+	AllowedHosts []string
+
+	// AllowedNetworks lists the network addresses that may be reached, e.g. "203.0.113.0/24".
+	//
+	// When not empty, any other address is denied. An address within an allowed network
+	// is allowed even if it is a private address.
+	AllowedNetworks []netip.Prefix
+
+	// DenyPrivateNetworks denies loopback, private, link-local (e.g. cloud metadata endpoints),
+	// multicast and unspecified addresses.
+	DenyPrivateNetworks bool
+
+	// MaxRedirects caps the number of redirects that are followed.
+	//
+	// A negative value disables redirects. Zero retains the default of [http.Client], i.e. 10 redirects.
+	MaxRedirects int
+
+	// AllowSchemeDowngrade allows redirects from https to http.
+	//
+	// By default, such redirects are denied.
+	AllowSchemeDowngrade bool
+}
+
+func (p *RemotePolicy) checksNetwork() bool {
+	return p.DenyPrivateNetworks || len(p.AllowedNetworks) > 0
+}
+
+// checkHost verifies that the host name of a URL is allowed.
+func (p *RemotePolicy) checkHost(host string) error {
+	if len(p.AllowedHosts) == 0 {
+		return nil
+	}
+
+	host = strings.ToLower(host)
+	for _, allowed := range p.AllowedHosts {
+		allowed = strings.ToLower(allowed)
+		if host == allowed {
+			return nil
+		}
+
+		if domain, isWildcard := strings.CutPrefix(allowed, "*"); isWildcard && strings.HasPrefix(domain, ".") && strings.HasSuffix(host, domain) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("host %q is not allowed: %w: %w", host, ErrRemotePolicy, ErrLoader)
+}
+
+// checkAddr verifies that a network address is allowed.
+func (p *RemotePolicy) checkAddr(addr netip.Addr) error {
+	addr = addr.Unmap()
+
+	for _, network := range p.AllowedNetworks {
+		if network.Contains(addr) {
+			return nil
+		}
+	}
+
+	if len(p.AllowedNetworks) > 0 {
+		return fmt.Errorf("address %v is not in an allowed network: %w: %w", addr, ErrRemotePolicy, ErrLoader)
+	}
+
+	if p.DenyPrivateNetworks && isPrivateAddr(addr) {
+		return fmt.Errorf("address %v is not allowed: %w: %w", addr, ErrRemotePolicy, ErrLoader)
+	}
+
+	return nil
+}
+
+func isPrivateAddr(addr netip.Addr) bool {
+	return addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified()
+}
+
+// control is a [net.Dialer] control hook, which checks the address to connect to, after DNS resolution.
+func (p *RemotePolicy) control(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("unexpected address %q: %w: %w", address, ErrRemotePolicy, ErrLoader)
+	}
+
+	return p.checkAddr(addrPort.Addr())
+}
+
+// checkRedirect enforces the policy on redirects, before any check defined by the client.
+func (p *RemotePolicy) checkRedirect(next func(*http.Request, []*http.Request) error) func(*http.Request, []*http.Request) error {
+	const defaultMaxRedirects = 10 // the default of net/http
+
+	maxRedirects := p.MaxRedirects
+	if maxRedirects == 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) > maxRedirects {
+			return fmt.Errorf("stopped after %d redirects: %w: %w", max(maxRedirects, 0), ErrRemotePolicy, ErrLoader)
+		}
+
+		if !p.AllowSchemeDowngrade && len(via) > 0 &&
+			strings.EqualFold(via[len(via)-1].URL.Scheme, "https") && !strings.EqualFold(req.URL.Scheme, "https") {
+			return fmt.Errorf("redirect from https to %s is not allowed: %w: %w", req.URL.Scheme, ErrRemotePolicy, ErrLoader)
+		}
+
+		if err := p.checkHost(req.URL.Hostname()); err != nil {
+			return err
+		}
+
+		if next != nil {
+			return next(req, via)
+		}
+
+		return nil
+	}
+}
+
+// guardClient derives an HTTP client that enforces the policy from the client provided by the options.
+func (p *RemotePolicy) guardClient(client *http.Client) (*http.Client, error) {
+	guarded := *client
+	guarded.CheckRedirect = p.checkRedirect(client.CheckRedirect)
+
+	if !p.checksNetwork() {
+		return &guarded, nil
+	}
+
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	transport, ok := base.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("network checks require an *http.Transport, but got %T: %w: %w", base, ErrRemotePolicy, ErrLoader)
+	}
+
+	if transport.DialTLSContext != nil || transport.DialTLS != nil { //nolint:staticcheck // the deprecated DialTLS would bypass the checks too
+		return nil, fmt.Errorf("network checks cannot be enforced with a custom TLS dialer: %w: %w", ErrRemotePolicy, ErrLoader)
+	}
+
+	const (
+		dialTimeout   = 30 * time.Second // same as http.DefaultTransport
+		dialKeepAlive = 30 * time.Second
+	)
+
+	transport = transport.Clone()
+	transport.Proxy = nil
+	transport.Dial = nil //nolint:staticcheck // make sure the deprecated Dial doesn't take precedence
+	transport.DialContext = (&net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: dialKeepAlive,
+		Control:   p.control,
+	}).DialContext
+	guarded.Transport = transport
+
+	return &guarded, nil
+}
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package loading
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestWithRemotePolicy(t *testing.T) {
+	loopback := netip.MustParsePrefix("127.0.0.0/8")
+
+	t.Run("should deny private networks", func(t *testing.T) {
+		var hits atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			hits.Add(1)
+			serveOK(rw, r)
+		}))
+		defer ts.Close()
+
+		_, err := LoadFromFileOrHTTP(ts.URL, WithRemotePolicy(RemotePolicy{DenyPrivateNetworks: true}))
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrRemotePolicy)
+		require.ErrorIs(t, err, ErrLoader)
+		assert.EqualT(t, int32(0), hits.Load())
+	})
+
+	t.Run("should not retry a denied destination", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(serveOK))
+		defer ts.Close()
+
+		start := time.Now()
+		_, err := LoadFromFileOrHTTP(ts.URL,
+			WithRemotePolicy(RemotePolicy{DenyPrivateNetworks: true}),
+			WithRetry(RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Second}),
+		)
+		require.ErrorIs(t, err, ErrRemotePolicy)
+		assert.LessT(t, time.Since(start), time.Second)
+	})
+
+	t.Run("should allow explicitly allowed networks", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(serveOK))
+		defer ts.Close()
+
+		b, err := LoadFromFileOrHTTP(ts.URL, WithRemotePolicy(RemotePolicy{
+			DenyPrivateNetworks: true,
+			AllowedNetworks:     []netip.Prefix{loopback},
+		}))
+		require.NoError(t, err)
+		assert.EqualT(t, "the content", string(b))
+	})
+
+	t.Run("should deny networks that are not allowed", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(serveOK))
+		defer ts.Close()
+
+		_, err := LoadFromFileOrHTTP(ts.URL, WithRemotePolicy(RemotePolicy{
+			AllowedNetworks: []netip.Prefix{netip.MustParsePrefix("203.0.113.0/24")},
+		}))
+		require.ErrorIs(t, err, ErrRemotePolicy)
+	})
+
+	t.Run("should deny private addresses after DNS resolution", func(t *testing.T) {
+		internal := httptest.NewServer(http.HandlerFunc(serveOK))
+		defer internal.Close()
+
+		internalURL, err := url.Parse(internal.URL)
+		require.NoError(t, err)
+		internalURL.Host = "localhost:" + internalURL.Port() // resolved by DNS
+
+		_, err = LoadFromFileOrHTTP(internalURL.String(), WithRemotePolicy(RemotePolicy{
+			AllowedHosts:        []string{"localhost"},
+			DenyPrivateNetworks: true,
+		}))
+		require.ErrorIs(t, err, ErrRemotePolicy)
+	})
+
+	t.Run("should check host names", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(serveOK))
+		defer ts.Close()
+
+		t.Run("with an allowed host", func(t *testing.T) {
+			_, err := LoadFromFileOrHTTP(ts.URL, WithRemotePolicy(RemotePolicy{AllowedHosts: []string{"127.0.0.1"}}))
+			require.NoError(t, err)
+		})
+
+		t.Run("with a host that is not allowed", func(t *testing.T) {
+			_, err := LoadFromFileOrHTTP(ts.URL, WithRemotePolicy(RemotePolicy{AllowedHosts: []string{"example.com"}}))
+			require.ErrorIs(t, err, ErrRemotePolicy)
+			require.ErrorIs(t, err, ErrLoader)
+		})
+
+		t.Run("with a redirect to a host that is not allowed", func(t *testing.T) {
+			redirector := httptest.NewServer(http.RedirectHandler("http://forbidden.example.com/spec", http.StatusFound))
+			defer redirector.Close()
+
+			_, err := LoadFromFileOrHTTP(redirector.URL, WithRemotePolicy(RemotePolicy{AllowedHosts: []string{"127.0.0.1"}}))
+			require.ErrorIs(t, err, ErrRemotePolicy)
+		})
+	})
+
+	t.Run("should cap redirects", func(t *testing.T) {
+		target := httptest.NewServer(http.HandlerFunc(serveOK))
+		defer target.Close()
+		hop := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusFound))
+		defer hop.Close()
+		start := httptest.NewServer(http.RedirectHandler(hop.URL, http.StatusFound))
+		defer start.Close()
+
+		t.Run("should follow redirects within the limit", func(t *testing.T) {
+			b, err := LoadFromFileOrHTTP(start.URL, WithRemotePolicy(RemotePolicy{MaxRedirects: 2}))
+			require.NoError(t, err)
+			assert.EqualT(t, "the content", string(b))
+		})
+
+		t.Run("should not follow redirects beyond the limit", func(t *testing.T) {
+			_, err := LoadFromFileOrHTTP(start.URL, WithRemotePolicy(RemotePolicy{MaxRedirects: 1}))
+			require.ErrorIs(t, err, ErrRemotePolicy)
+		})
+
+		t.Run("should not follow any redirect", func(t *testing.T) {
+			_, err := LoadFromFileOrHTTP(hop.URL, WithRemotePolicy(RemotePolicy{MaxRedirects: -1}))
+			require.ErrorIs(t, err, ErrRemotePolicy)
+		})
+	})
+
+	t.Run("should not downgrade the scheme on redirect", func(t *testing.T) {
+		plain := httptest.NewServer(http.HandlerFunc(serveOK))
+		defer plain.Close()
+		secure := httptest.NewTLSServer(http.RedirectHandler(plain.URL, http.StatusFound))
+		defer secure.Close()
+
+		t.Run("by default", func(t *testing.T) {
+			_, err := LoadFromFileOrHTTP(secure.URL,
+				WithHTTPClient(secure.Client()),
+				WithRemotePolicy(RemotePolicy{}),
+			)
+			require.ErrorIs(t, err, ErrRemotePolicy)
+		})
+
+		t.Run("unless allowed", func(t *testing.T) {
+			b, err := LoadFromFileOrHTTP(secure.URL,
+				WithHTTPClient(secure.Client()),
+				WithRemotePolicy(RemotePolicy{AllowSchemeDowngrade: true}),
+			)
+			require.NoError(t, err)
+			assert.EqualT(t, "the content", string(b))
+		})
+	})
+
+	t.Run("should fail with a transport that cannot be guarded", func(t *testing.T) {
+		client := &http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}
+
+		_, err := LoadFromFileOrHTTP("http://example.com", WithHTTPClient(client), WithRemotePolicy(RemotePolicy{DenyPrivateNetworks: true}))
+		require.ErrorIs(t, err, ErrRemotePolicy)
+	})
+}
+
+func TestRemotePolicy(t *testing.T) {
+	t.Run("should match host names", func(t *testing.T) {
+		p := RemotePolicy{AllowedHosts: []string{"example.com", "*.Example.org"}}
+
+		require.NoError(t, p.checkHost("example.com"))
+		require.NoError(t, p.checkHost("EXAMPLE.COM"))
+		require.NoError(t, p.checkHost("api.example.org"))
+		require.Error(t, p.checkHost("api.example.com"))
+		require.Error(t, p.checkHost("example.org"))
+		require.Error(t, p.checkHost("badexample.org"))
+	})
+
+	t.Run("should check addresses", func(t *testing.T) {
+		p := RemotePolicy{DenyPrivateNetworks: true}
+
+		for _, addr := range []string{"127.0.0.1", "::1", "10.1.2.3", "192.168.0.1", "169.254.169.254", "fe80::1", "0.0.0.0", "::ffff:127.0.0.1", "224.0.0.1"} {
+			require.Error(t, p.checkAddr(netip.MustParseAddr(addr)), addr)
+		}
+
+		for _, addr := range []string{"203.0.113.1", "2001:db8::1"} {
+			require.NoError(t, p.checkAddr(netip.MustParseAddr(addr)), addr)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package loading
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy tells the remote file loader how to retry transient failures.
+//
+// A failure is considered transient when no response could be received from the server
+// (e.g. a network error), or when the server responds with one of the retryable status codes.
+//
+// Attempts are spaced with an exponential backoff, with jitter. Whenever the server
+// sends a Retry-After header, the loader waits at least for the requested duration.
+//
+// All attempts are constrained by the timeout set by [WithTimeout] and by the deadline of the context,
+// if any: the loader gives up as soon as the next attempt would start after that deadline.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	//
+	// Defaults to 3.
+	MaxAttempts int
+
+	// InitialBackoff is the base delay before the first retry. The delay doubles with every retry.
+	//
+	// Defaults to 100ms.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts, not accounting for a Retry-After header.
+	//
+	// Defaults to 5s.
+	MaxBackoff time.Duration
+
+	// RetryableStatusCodes lists the HTTP status codes that trigger a retry.
+	//
+	// Defaults to 429 (Too Many Requests), 502 (Bad Gateway), 503 (Service Unavailable) and 504 (Gateway Timeout).
+	RetryableStatusCodes []int
+}
+
+// DefaultRetryPolicy returns the retry policy used by [WithRetry] for unset fields.
+func DefaultRetryPolicy() RetryPolicy {
+	const (
+		defaultMaxAttempts    = 3
+		defaultInitialBackoff = 100 * time.Millisecond
+		defaultMaxBackoff     = 5 * time.Second
+	)
+
+	return RetryPolicy{
+		MaxAttempts:    defaultMaxAttempts,
+		InitialBackoff: defaultInitialBackoff,
+		MaxBackoff:     defaultMaxBackoff,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	d := DefaultRetryPolicy()
+
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = d.MaxAttempts
+	}
+
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = d.InitialBackoff
+	}
+
+	if p.MaxBackoff <= 0 {
+		p.MaxBackoff = d.MaxBackoff
+	}
+
+	if p.RetryableStatusCodes == nil {
+		p.RetryableStatusCodes = d.RetryableStatusCodes
+	}
+
+	return p
+}
+
+// next tells whether a failed attempt should be retried, and how long to wait before doing so.
+//
+// resp is the response received for the failed attempt, or nil if none was received.
+// Failures due to a [RemotePolicy] are never retried.
+func (p *RetryPolicy) next(ctx context.Context, attempt int, resp *http.Response, err error) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil || errors.Is(err, ErrRemotePolicy) {
+		return 0, false
+	}
+
+	delay := p.backoff(attempt)
+
+	if resp != nil {
+		if !slices.Contains(p.RetryableStatusCodes, resp.StatusCode) {
+			return 0, false
+		}
+
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			delay = max(delay, retryAfter)
+		}
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(delay).After(deadline) {
+		// the next attempt would start after the deadline
+		return 0, false
+	}
+
+	return delay, true
+}
+
+// backoff computes an exponential backoff with "equal jitter", i.e. a random delay between half and
+// the full value of the exponential backoff.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	backoff := p.MaxBackoff
+	if shift := attempt - 1; shift < 32 && p.InitialBackoff<<shift > 0 {
+		backoff = min(p.InitialBackoff<<shift, p.MaxBackoff)
+	}
+
+	half := backoff / 2
+
+	return half + rand.N(backoff-half+1) //nolint:gosec // jitter does not require a cryptographically secure generator
+}
+
+// parseRetryAfter parses the value of a Retry-After header, expressed either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return max(date.Sub(now), 0), true
+}
+
+// sleepContext waits for the given duration, or until ctx is done.
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,244 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package loading
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestWithRetry(t *testing.T) {
+	const content = "the content"
+	fastRetry := RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     2 * time.Millisecond,
+	}
+
+	// serveFlaky fails with the given status code until the number of failures is reached
+	serveFlaky := func(hits *atomic.Int32, failures int32, status int) http.HandlerFunc {
+		return func(rw http.ResponseWriter, _ *http.Request) {
+			if hits.Add(1) <= failures {
+				rw.WriteHeader(status)
+
+				return
+			}
+			_, _ = rw.Write([]byte(content))
+		}
+	}
+
+	t.Run("should not retry by default", func(t *testing.T) {
+		var hits atomic.Int32
+		ts := httptest.NewServer(serveFlaky(&hits, 1, http.StatusServiceUnavailable))
+		defer ts.Close()
+
+		_, err := LoadFromFileOrHTTP(ts.URL)
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrLoader)
+		assert.EqualT(t, int32(1), hits.Load())
+	})
+
+	t.Run("should retry on retryable status codes", func(t *testing.T) {
+		for _, status := range []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		} {
+			t.Run(strconv.Itoa(status), func(t *testing.T) {
+				var hits atomic.Int32
+				ts := httptest.NewServer(serveFlaky(&hits, 2, status))
+				defer ts.Close()
+
+				b, err := LoadFromFileOrHTTP(ts.URL, WithRetry(fastRetry))
+				require.NoError(t, err)
+				assert.EqualT(t, content, string(b))
+				assert.EqualT(t, int32(3), hits.Load())
+			})
+		}
+	})
+
+	t.Run("should not retry on other status codes", func(t *testing.T) {
+		var hits atomic.Int32
+		ts := httptest.NewServer(serveFlaky(&hits, 1, http.StatusNotFound))
+		defer ts.Close()
+
+		_, err := LoadFromFileOrHTTP(ts.URL, WithRetry(fastRetry))
+		require.Error(t, err)
+		assert.EqualT(t, int32(1), hits.Load())
+	})
+
+	t.Run("should retry on custom status codes", func(t *testing.T) {
+		var hits atomic.Int32
+		ts := httptest.NewServer(serveFlaky(&hits, 1, http.StatusInternalServerError))
+		defer ts.Close()
+
+		policy := fastRetry
+		policy.RetryableStatusCodes = []int{http.StatusInternalServerError}
+
+		_, err := LoadFromFileOrHTTP(ts.URL, WithRetry(policy))
+		require.NoError(t, err)
+		assert.EqualT(t, int32(2), hits.Load())
+	})
+
+	t.Run("should give up after the maximum number of attempts", func(t *testing.T) {
+		var hits atomic.Int32
+		ts := httptest.NewServer(serveFlaky(&hits, 10, http.StatusBadGateway))
+		defer ts.Close()
+
+		_, err := LoadFromFileOrHTTP(ts.URL, WithRetry(fastRetry))
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrLoader)
+		assert.EqualT(t, int32(4), hits.Load())
+	})
+
+	t.Run("should retry on network errors", func(t *testing.T) {
+		// a listener that is closed right away: connections are refused
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := l.Addr().String()
+		require.NoError(t, l.Close())
+
+		var attempts atomic.Int32
+		client := &http.Client{
+			Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				attempts.Add(1)
+
+				return http.DefaultTransport.RoundTrip(req)
+			}),
+		}
+
+		_, err = LoadFromFileOrHTTP("http://"+addr, WithRetry(fastRetry), WithHTTPClient(client))
+		require.Error(t, err)
+		assert.EqualT(t, int32(4), attempts.Load())
+	})
+
+	t.Run("should honor Retry-After", func(t *testing.T) {
+		const retryAfter = time.Second
+
+		var (
+			hits  atomic.Int32
+			first time.Time
+		)
+		ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			if hits.Add(1) == 1 {
+				first = time.Now()
+				rw.Header().Set("Retry-After", "1")
+				rw.WriteHeader(http.StatusTooManyRequests)
+
+				return
+			}
+			assert.GreaterOrEqualT(t, time.Since(first), retryAfter)
+			_, _ = rw.Write([]byte(content))
+		}))
+		defer ts.Close()
+
+		b, err := LoadFromFileOrHTTP(ts.URL, WithRetry(fastRetry))
+		require.NoError(t, err)
+		assert.EqualT(t, content, string(b))
+		assert.EqualT(t, int32(2), hits.Load())
+	})
+
+	t.Run("should not retry beyond the timeout", func(t *testing.T) {
+		var hits atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			hits.Add(1)
+			rw.Header().Set("Retry-After", "60")
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer ts.Close()
+
+		start := time.Now()
+		_, err := LoadFromFileOrHTTP(ts.URL, WithRetry(fastRetry), WithTimeout(time.Second))
+		require.Error(t, err)
+		assert.EqualT(t, int32(1), hits.Load())
+		assert.LessT(t, time.Since(start), time.Second)
+	})
+
+	t.Run("should stop retrying when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+
+		var hits atomic.Int32
+		ts := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			hits.Add(1)
+			cancel()
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer ts.Close()
+
+		_, err := LoadFromFileOrHTTPContext(ctx, ts.URL, WithRetry(fastRetry))
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrLoader)
+		assert.EqualT(t, int32(1), hits.Load())
+	})
+}
+
+func TestRetryPolicy(t *testing.T) {
+	t.Run("should fill in defaults", func(t *testing.T) {
+		p := RetryPolicy{MaxAttempts: 5}.withDefaults()
+		d := DefaultRetryPolicy()
+
+		assert.EqualT(t, 5, p.MaxAttempts)
+		assert.EqualT(t, d.InitialBackoff, p.InitialBackoff)
+		assert.EqualT(t, d.MaxBackoff, p.MaxBackoff)
+		assert.Equal(t, d.RetryableStatusCodes, p.RetryableStatusCodes)
+	})
+
+	t.Run("should compute an exponential backoff with jitter", func(t *testing.T) {
+		p := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+		for attempt, expected := range map[int]time.Duration{
+			1:  100 * time.Millisecond,
+			2:  200 * time.Millisecond,
+			3:  400 * time.Millisecond,
+			4:  800 * time.Millisecond,
+			5:  time.Second,
+			80: time.Second,
+		} {
+			for range 10 {
+				backoff := p.backoff(attempt)
+				assert.GreaterOrEqualT(t, backoff, expected/2)
+				assert.LessOrEqualT(t, backoff, expected)
+			}
+		}
+	})
+
+	t.Run("should parse Retry-After", func(t *testing.T) {
+		now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		for _, tc := range []struct {
+			Value    string
+			Expected time.Duration
+			OK       bool
+		}{
+			{Value: ""},
+			{Value: "invalid"},
+			{Value: "-1"},
+			{Value: "120", Expected: 2 * time.Minute, OK: true},
+			{Value: now.Add(time.Minute).Format(http.TimeFormat), Expected: time.Minute, OK: true},
+			{Value: now.Add(-time.Minute).Format(http.TimeFormat), OK: true},
+		} {
+			t.Run(tc.Value, func(t *testing.T) {
+				d, ok := parseRetryAfter(tc.Value, now)
+				assert.EqualT(t, tc.OK, ok)
+				assert.EqualT(t, tc.Expected, d)
+			})
+		}
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package loading
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// SchemeLoader loads a document from a URI with a given scheme.
+//
+// A SchemeLoader is registered for a scheme with [WithSchemeLoader].
+// The URI is passed to the loader as parsed by [url.Parse].
+//
+// The context is the one passed to [LoadFromFileOrHTTPContext], or [context.Background] when none is provided.
+type SchemeLoader func(ctx context.Context, u *url.URL) ([]byte, error)
+
+// registry builds the scheme-to-loader registry.
+//
+// The built-in loaders handle the "http", "https" and "file" schemes.
+// They are overridden by loaders registered with [WithSchemeLoader].
+func (o options) registry(ctx context.Context, local, remote func(string) ([]byte, error)) map[string]func(string) ([]byte, error) {
+	registry := map[string]func(string) ([]byte, error){
+		"http":  remote,
+		"https": remote,
+		"file":  local,
+	}
+
+	for scheme, loader := range o.schemes {
+		registry[scheme] = loadURI(ctx, loader)
+	}
+
+	return registry
+}
+
+// routesScheme tells if pth is routed to the loader for its scheme.
+//
+// The built-in "http", "https" and "file" loaders require the "://" separator after the scheme,
+// so that a local file such as "http:file" is not loaded remotely.
+// Loaders registered with [WithSchemeLoader] don't (e.g. "data:" URIs don't use it).
+func (o options) routesScheme(pth, scheme string) bool {
+	if _, isRegistered := o.schemes[scheme]; isRegistered {
+		return true
+	}
+
+	return strings.HasPrefix(pth[len(scheme):], "://")
+}
+
+// loadURI adapts a [SchemeLoader] to the signature of the loaders returned by [LoadStrategy].
+func loadURI(ctx context.Context, loader SchemeLoader) func(string) ([]byte, error) {
+	return func(pth string) ([]byte, error) {
+		u, err := url.Parse(pth)
+		if err != nil {
+			return nil, &LoadError{Source: pth, Location: pth, Err: err}
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, &LoadError{Source: pth, Location: pth, Err: err}
+		}
+
+		data, err := loader(ctx, u)
+		if err != nil {
+			return nil, &LoadError{Source: pth, Location: pth, Err: err}
+		}
+
+		return data, nil
+	}
+}
+
+// uriScheme returns the lower-cased scheme of a URI, as defined by RFC 3986, section 3.1.
+//
+// Single-letter schemes are not considered, since these are most likely windows drive letters (e.g. "C:\folder").
+// The comparison with registered schemes is case-insensitive, as URI schemes are.
+//
+// Note that the "://" separator is not required here (e.g. "data:" URIs don't use it): see [options.routesScheme].
+// A local file whose name merely starts with "http" (e.g. "httpbin.json") is not a URI.
+func uriScheme(pth string) (string, bool) {
+	scheme, _, found := strings.Cut(pth, ":")
+	if !found || len(scheme) < 2 || !isASCIILetter(scheme[0]) {
+		return "", false
+	}
+
+	for i := 1; i < len(scheme); i++ {
+		c := scheme[i]
+		if !isASCIILetter(c) && !isASCIIDigit(c) && c != '+' && c != '-' && c != '.' {
+			return "", false
+		}
+	}
+
+	return strings.ToLower(scheme), true
+}
+
+func isASCIIDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
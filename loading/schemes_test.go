@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package loading
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestWithSchemeLoader(t *testing.T) {
+	memStore := map[string][]byte{
+		"specs/petstore": yamlPetStore,
+	}
+	memLoader := func(_ context.Context, u *url.URL) ([]byte, error) {
+		data, ok := memStore[u.Host+u.Path]
+		if !ok {
+			return nil, errors.New("not found")
+		}
+
+		return data, nil
+	}
+
+	t.Run("should load from a registered scheme", func(t *testing.T) {
+		b, err := LoadFromFileOrHTTP("mem://specs/petstore", WithSchemeLoader("mem", memLoader))
+		require.NoError(t, err)
+		assert.YAMLEqT(t, string(yamlPetStore), string(b))
+	})
+
+	t.Run("should match a registered scheme regardless of case", func(t *testing.T) {
+		b, err := LoadFromFileOrHTTP("MEM://specs/petstore", WithSchemeLoader("Mem", memLoader))
+		require.NoError(t, err)
+		assert.YAMLEqT(t, string(yamlPetStore), string(b))
+	})
+
+	t.Run("should load from a registered scheme without authority", func(t *testing.T) {
+		dataLoader := func(_ context.Context, u *url.URL) ([]byte, error) {
+			_, data, _ := strings.Cut(u.Opaque, ",")
+
+			return []byte(data), nil
+		}
+
+		b, err := LoadFromFileOrHTTP("data:text/plain,the content", WithSchemeLoader("data", dataLoader))
+		require.NoError(t, err)
+		assert.EqualT(t, "the content", string(b))
+	})
+
+	t.Run("should propagate errors from a registered scheme", func(t *testing.T) {
+		_, err := LoadFromFileOrHTTP("mem://specs/unknown", WithSchemeLoader("mem", memLoader))
+		require.Error(t, err)
+	})
+
+	t.Run("should pass the context to a registered scheme", func(t *testing.T) {
+		type ctxKey struct{}
+		ctx := context.WithValue(t.Context(), ctxKey{}, "value")
+		ctxLoader := func(ctx context.Context, _ *url.URL) ([]byte, error) {
+			v, _ := ctx.Value(ctxKey{}).(string)
+
+			return []byte(v), nil
+		}
+
+		b, err := LoadFromFileOrHTTPContext(ctx, "spec://resolver", WithSchemeLoader("spec", ctxLoader))
+		require.NoError(t, err)
+		assert.EqualT(t, "value", string(b))
+
+		t.Run("should not call a registered scheme when the context is canceled", func(t *testing.T) {
+			canceled, cancel := context.WithCancel(ctx)
+			cancel()
+
+			_, err := LoadFromFileOrHTTPContext(canceled, "spec://resolver", WithSchemeLoader("spec", ctxLoader))
+			require.ErrorIs(t, err, context.Canceled)
+			require.ErrorIs(t, err, ErrLoader)
+		})
+	})
+
+	t.Run("should override the built-in http loader", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(serveKO))
+		defer ts.Close()
+
+		b, err := LoadFromFileOrHTTP(ts.URL, WithSchemeLoader("http", func(_ context.Context, u *url.URL) ([]byte, error) {
+			return []byte(u.Scheme), nil
+		}))
+		require.NoError(t, err)
+		assert.EqualT(t, "http", string(b))
+	})
+
+	t.Run("should override the built-in file loader", func(t *testing.T) {
+		b, err := LoadFromFileOrHTTP("file:///folder/file", WithSchemeLoader("file", func(_ context.Context, u *url.URL) ([]byte, error) {
+			return []byte(u.Path), nil
+		}))
+		require.NoError(t, err)
+		assert.EqualT(t, "/folder/file", string(b))
+	})
+
+	t.Run("should require the :// separator for the built-in loaders", func(t *testing.T) {
+		mapfs := fstest.MapFS{
+			"http:file":      &fstest.MapFile{Data: []byte("http content")},
+			"HTTPS:file":     &fstest.MapFile{Data: []byte("https content")},
+			"file:file":      &fstest.MapFile{Data: []byte("file content")},
+			"data:spec.yaml": &fstest.MapFile{Data: []byte("data content")},
+		}
+
+		for pth, expected := range map[string]string{
+			"http:file":      "http content",
+			"HTTPS:file":     "https content",
+			"file:file":      "file content",
+			"data:spec.yaml": "data content",
+		} {
+			b, err := LoadFromFileOrHTTP(pth, WithFS(mapfs))
+			require.NoError(t, err)
+			assert.EqualT(t, expected, string(b))
+		}
+	})
+
+	t.Run("should fall back to the local loader for an unregistered scheme", func(t *testing.T) {
+		mapfs := fstest.MapFS{"unknown:file": &fstest.MapFile{Data: []byte("content")}}
+
+		b, err := LoadFromFileOrHTTP("unknown:file", WithFS(mapfs), WithSchemeLoader("mem", memLoader))
+		require.NoError(t, err)
+		assert.EqualT(t, "content", string(b))
+	})
+}
+
+func TestURIScheme(t *testing.T) {
+	for _, tc := range []struct {
+		Path     string
+		Expected string
+		IsURI    bool
+	}{
+		{Path: "http://host/file", Expected: "http", IsURI: true},
+		{Path: "HTTPS://host/file", Expected: "https", IsURI: true},
+		{Path: "file:///folder/file", Expected: "file", IsURI: true},
+		{Path: "data:text/plain,content", Expected: "data", IsURI: true},
+		{Path: "git+ssh://host/repo", Expected: "git+ssh", IsURI: true},
+		{Path: "httpbin.json"},
+		{Path: "http"},
+		{Path: "./folder/file"},
+		{Path: "C:/folder/file"},
+		{Path: `C:\folder\file`},
+		{Path: "1http://host"},
+		{Path: "ht_tp://host"},
+		{Path: ":file"},
+	} {
+		t.Run(tc.Path, func(t *testing.T) {
+			scheme, isURI := uriScheme(tc.Path)
+			assert.EqualT(t, tc.IsURI, isURI)
+			assert.EqualT(t, tc.Expected, scheme)
+		})
+	}
+}
@@ -4,7 +4,9 @@
 package loading
 
 import (
+	"context"
 	"encoding/json"
+	"iter"
 	"path/filepath"
 
 	"github.com/go-openapi/swag/yamlutils"
@@ -18,7 +20,15 @@ func YAMLMatcher(path string) bool {
 
 // YAMLDoc loads a yaml document from either http or a file and converts it to json.
 func YAMLDoc(path string, opts ...Option) (json.RawMessage, error) {
-	yamlDoc, err := YAMLData(path, opts...)
+	return YAMLDocContext(context.Background(), path, opts...)
+}
+
+// YAMLDocContext loads a yaml document from either http or a file and converts it to json,
+// with a caller-provided context.
+//
+// See [LoadFromFileOrHTTPContext].
+func YAMLDocContext(ctx context.Context, path string, opts ...Option) (json.RawMessage, error) {
+	yamlDoc, err := YAMLDataContext(ctx, path, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -28,10 +38,48 @@ func YAMLDoc(path string, opts ...Option) (json.RawMessage, error) {
 
 // YAMLData loads a yaml document from either http or a file.
 func YAMLData(path string, opts ...Option) (any, error) {
-	data, err := LoadFromFileOrHTTP(path, opts...)
+	return YAMLDataContext(context.Background(), path, opts...)
+}
+
+// YAMLDataContext loads a yaml document from either http or a file, with a caller-provided context.
+//
+// See [LoadFromFileOrHTTPContext].
+func YAMLDataContext(ctx context.Context, path string, opts ...Option) (any, error) {
+	data, err := LoadFromFileOrHTTPContext(ctx, path, opts...)
 	if err != nil {
 		return nil, err
 	}
 
 	return yamlutils.BytesToYAMLDoc(data)
 }
+
+// YAMLDocuments loads a stream of yaml documents, separated by "---", from either http or a file,
+// and converts each document to json.
+//
+// The document is loaded when the iteration starts. The iteration stops after the first error.
+//
+// See [yamlutils.DocumentsToJSON].
+func YAMLDocuments(path string, opts ...Option) iter.Seq2[json.RawMessage, error] {
+	return YAMLDocumentsContext(context.Background(), path, opts...)
+}
+
+// YAMLDocumentsContext loads a stream of yaml documents from either http or a file, and converts each
+// document to json, with a caller-provided context.
+//
+// See [YAMLDocuments] and [LoadFromFileOrHTTPContext].
+func YAMLDocumentsContext(ctx context.Context, path string, opts ...Option) iter.Seq2[json.RawMessage, error] {
+	return func(yield func(json.RawMessage, error) bool) {
+		data, err := LoadFromFileOrHTTPContext(ctx, path, opts...)
+		if err != nil {
+			yield(nil, err)
+
+			return
+		}
+
+		for doc, err := range yamlutils.DocumentsToJSON(data) {
+			if !yield(doc, err) || err != nil {
+				return
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@
 package loading
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -41,4 +42,60 @@ func TestYAMLDoc(t *testing.T) {
 		_, err := YAMLDoc(ts.URL)
 		require.Error(t, err)
 	})
+
+	t.Run("should not retrieve any doc with a canceled context", func(t *testing.T) {
+		serv := httptest.NewServer(http.HandlerFunc(serveYAMLPetStore))
+		defer serv.Close()
+
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		_, err := YAMLDocContext(ctx, serv.URL)
+		require.ErrorIs(t, err, context.Canceled)
+		require.ErrorIs(t, err, ErrLoader)
+	})
+}
+
+func TestYAMLDocuments(t *testing.T) {
+	t.Run("should retrieve a stream of YAML documents as JSON", func(t *testing.T) {
+		serv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			_, _ = rw.Write([]byte("a: 1\n---\nb: 2\n---\n"))
+		}))
+		defer serv.Close()
+
+		var docs []string
+		for doc, err := range YAMLDocuments(serv.URL) {
+			require.NoError(t, err)
+			docs = append(docs, string(doc))
+		}
+		assert.Equal(t, []string{`{"a":1}`, `{"b":2}`}, docs)
+	})
+
+	t.Run("should not retrieve any doc", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(serveKO))
+		defer ts.Close()
+
+		var count int
+		for doc, err := range YAMLDocuments(ts.URL) {
+			count++
+			require.ErrorIs(t, err, ErrLoader)
+			assert.Nil(t, doc)
+		}
+		assert.EqualT(t, 1, count)
+	})
+
+	t.Run("should stop on an invalid document", func(t *testing.T) {
+		serv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+			_, _ = rw.Write([]byte("a: 1\n---\na: [\n---\nb: 2\n"))
+		}))
+		defer serv.Close()
+
+		var errs []error
+		for _, err := range YAMLDocuments(serv.URL) {
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}
+		require.Len(t, errs, 1)
+	})
 }
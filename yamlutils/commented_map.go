@@ -0,0 +1,319 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/go-openapi/swag/jsonutils"
+	"github.com/go-openapi/swag/typeutils"
+	yaml "go.yaml.in/yaml/v3"
+)
+
+var (
+	_ yaml.Marshaler   = YAMLCommentedMapSlice{}
+	_ yaml.Unmarshaler = &YAMLCommentedMapSlice{}
+)
+
+// YAMLComments holds the comments attached to a key in a YAML object.
+//
+// Comments are retained as found in the source, including the leading "#".
+type YAMLComments struct {
+	// Head is the comment on the lines preceding the key.
+	Head string
+	// Line is the comment at the end of the line of the key.
+	Line string
+	// Foot is the comment on the lines following the value.
+	Foot string
+}
+
+// IsZero reports whether there are no comments.
+func (c YAMLComments) IsZero() bool {
+	return c == YAMLComments{}
+}
+
+// YAMLCommentedMapItem represents the value of a key in a YAML object held by [YAMLCommentedMapSlice],
+// together with the comments attached to this key.
+type YAMLCommentedMapItem struct {
+	Key      string
+	Value    any
+	Comments YAMLComments
+}
+
+// YAMLCommentedMapSlice represents a YAML object, with the order of keys and the comments maintained.
+//
+// It behaves like [YAMLMapSlice], but retains the comments attached to the keys when unmarshaling YAML,
+// and renders them back when marshaling YAML.
+// Nested objects are represented as [YAMLCommentedMapSlice] too.
+//
+// Comments are not rendered in JSON.
+//
+// Unlike [YAMLMapSlice], [YAMLCommentedMapSlice.MarshalYAML] returns a [yaml.Node] and not bytes,
+// so a [YAMLCommentedMapSlice] may be passed directly to [yaml.Marshal].
+type YAMLCommentedMapSlice []YAMLCommentedMapItem
+
+// OrderedItems iterates over the keys and values of this YAML object, in order.
+//
+// It implements [ifaces.Ordered].
+func (s YAMLCommentedMapSlice) OrderedItems() iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		for _, item := range s {
+			if !yield(item.Key, item.Value) {
+				return
+			}
+		}
+	}
+}
+
+// SetOrderedItems implements [ifaces.SetOrdered]: it merges keys passed by the iterator argument
+// into the [YAMLCommentedMapSlice].
+//
+// The comments attached to existing keys are retained. New keys are appended without comments.
+func (s *YAMLCommentedMapSlice) SetOrderedItems(items iter.Seq2[string, any]) {
+	if items == nil {
+		// force receiver to be a nil slice
+		*s = nil
+
+		return
+	}
+
+	m := *s
+	idx := make(map[string]int, len(m))
+
+	for i, item := range m {
+		idx[item.Key] = i
+	}
+
+	for k, v := range items {
+		if i, ok := idx[k]; ok {
+			m[i].Value = v
+
+			continue
+		}
+
+		idx[k] = len(m)
+		m = append(m, YAMLCommentedMapItem{Key: k, Value: v})
+	}
+
+	*s = m
+}
+
+// MarshalJSON renders this YAML object as JSON bytes, without comments.
+//
+// The difference with standard JSON marshaling is that the order of keys is maintained.
+func (s YAMLCommentedMapSlice) MarshalJSON() ([]byte, error) {
+	if typeutils.IsNil(s) {
+		return []byte("null"), nil
+	}
+
+	js := make(jsonutils.JSONMapSlice, 0, len(s))
+	for _, item := range s {
+		js = append(js, jsonutils.JSONMapItem{Key: item.Key, Value: item.Value})
+	}
+
+	return js.MarshalJSON()
+}
+
+// UnmarshalJSON builds this YAML object from JSON bytes.
+//
+// The difference with standard JSON marshaling is that the order of keys is maintained.
+func (s *YAMLCommentedMapSlice) UnmarshalJSON(data []byte) error {
+	var js jsonutils.JSONMapSlice
+
+	if err := js.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	if js == nil {
+		*s = nil
+
+		return nil
+	}
+
+	m := make(YAMLCommentedMapSlice, 0, len(js))
+	for _, item := range js {
+		m = append(m, YAMLCommentedMapItem{Key: item.Key, Value: item.Value})
+	}
+
+	*s = m
+
+	return nil
+}
+
+// MarshalYAML produces a YAML mapping node, with the order of keys and the comments maintained.
+//
+// It implements [yaml.Marshaler].
+func (s YAMLCommentedMapSlice) MarshalYAML() (any, error) {
+	if typeutils.IsNil(s) {
+		return &yaml.Node{
+			Kind:  yaml.ScalarNode,
+			Tag:   yamlNull,
+			Value: "null",
+		}, nil
+	}
+
+	return s.mappingNode(0)
+}
+
+// UnmarshalYAML builds a [YAMLCommentedMapSlice] from a YAML mapping or document [yaml.Node].
+//
+// When a document node is passed, the comments attached to the document are retained too:
+// the head comment is merged into the head comment of the first key, and the foot comment
+// into the foot comment of the last key.
+//
+// It implements [yaml.Unmarshaler].
+func (s *YAMLCommentedMapSlice) UnmarshalYAML(node *yaml.Node) error {
+	return s.UnmarshalYAMLWith(node)
+}
+
+// UnmarshalYAMLWith builds a [YAMLCommentedMapSlice] from a YAML mapping or document [yaml.Node], with options.
+//
+// See [YAMLCommentedMapSlice.UnmarshalYAML]. Use [WithLimits] to bound the resources spent on the document.
+func (s *YAMLCommentedMapSlice) UnmarshalYAMLWith(node *yaml.Node, opts ...Option) error {
+	o := optionsWithDefaults(opts)
+	w := o.walker()
+	w.comments = true
+
+	if node == nil || node.Kind != yaml.DocumentNode {
+		return s.unmarshalYAML(w, node, 0)
+	}
+
+	if len(node.Content) != 1 || node.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("only YAML documents that are objects are supported: %w", ErrYAML)
+	}
+
+	if err := s.unmarshalYAML(w, node.Content[0], 1); err != nil {
+		return err
+	}
+
+	m := *s
+	if len(m) > 0 {
+		m[0].Comments.Head = joinComments(node.HeadComment, m[0].Comments.Head)
+		m[len(m)-1].Comments.Foot = joinComments(m[len(m)-1].Comments.Foot, node.FootComment)
+	}
+
+	return nil
+}
+
+func (s *YAMLCommentedMapSlice) unmarshalYAML(w *yamlWalker, node *yaml.Node, depth int) error {
+	if err := w.checkDepth(depth); err != nil {
+		return withPosition(node, err)
+	}
+
+	if node == nil {
+		*s = nil
+
+		return nil
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return withPosition(node, fmt.Errorf("only YAML objects are supported: %w", ErrYAML))
+	}
+
+	if typeutils.IsNil(*s) {
+		// allow to unmarshal with a simple var declaration (nil slice)
+		*s = YAMLCommentedMapSlice{}
+	}
+
+	const sensibleAllocDivider = 2
+	m := slices.Grow((*s)[:0], len(node.Content)/sensibleAllocDivider)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+
+		if err := w.account(); err != nil { // account the key node
+			return withPosition(keyNode, err)
+		}
+
+		k, err := yamlStringScalarC(keyNode)
+		if err != nil {
+			return fmt.Errorf("unable to decode YAML map key: %w: %w", withPosition(keyNode, err), ErrYAML)
+		}
+
+		v, err := w.child(valueNode, k, depth+1)
+		if err != nil {
+			return fmt.Errorf("unable to process YAML map value for key %q: %w: %w", k, err, ErrYAML)
+		}
+
+		comments := YAMLComments{
+			Head: keyNode.HeadComment,
+			Line: keyNode.LineComment,
+			Foot: keyNode.FootComment,
+		}
+		if comments.Line == "" {
+			comments.Line = valueNode.LineComment
+		}
+
+		m = append(m, YAMLCommentedMapItem{Key: k, Value: v, Comments: comments})
+	}
+
+	*s = m
+
+	return nil
+}
+
+func (s YAMLCommentedMapSlice) mappingNode(depth int) (*yaml.Node, error) {
+	if depth > defaultMaxNestingDepth {
+		return nil, errMaxNestingDepth
+	}
+
+	const sensibleAllocMultiplier = 2 // nodes concatenate (key,value) sequences
+	n := &yaml.Node{
+		Kind:    yaml.MappingNode,
+		Content: make([]*yaml.Node, 0, len(s)*sensibleAllocMultiplier),
+	}
+
+	for _, item := range s {
+		valueNode, err := json2yaml(item.Value, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		keyNode := &yaml.Node{
+			Kind:        yaml.ScalarNode,
+			Tag:         yamlStringScalar,
+			Value:       item.Key,
+			HeadComment: item.Comments.Head,
+			LineComment: item.Comments.Line,
+			FootComment: item.Comments.Foot,
+		}
+		placeLineComment(keyNode, valueNode)
+
+		n.Content = append(n.Content, keyNode, valueNode)
+	}
+
+	return n, nil
+}
+
+// placeLineComment moves the line comment of a key to its value, unless the value is rendered
+// as a block on the next lines: only then may a line comment follow the key.
+//
+// A line comment already set on the value is kept.
+func placeLineComment(keyNode, valueNode *yaml.Node) {
+	if isBlockCollection(valueNode) || keyNode.LineComment == "" || valueNode.LineComment != "" {
+		return
+	}
+
+	valueNode.LineComment, keyNode.LineComment = keyNode.LineComment, ""
+}
+
+func isBlockCollection(node *yaml.Node) bool {
+	return (node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode) &&
+		len(node.Content) > 0 &&
+		node.Style&yaml.FlowStyle == 0
+}
+
+// joinComments joins comment blocks, separated by a blank line.
+func joinComments(first, second string) string {
+	switch {
+	case first == "":
+		return second
+	case second == "":
+		return first
+	default:
+		return first + "\n\n" + second
+	}
+}
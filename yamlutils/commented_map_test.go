@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+	yaml "go.yaml.in/yaml/v3"
+)
+
+const commentedFixture = `# head of openapi
+openapi: 3.1.0 # line of openapi
+# head of info
+info: # line of info
+    # head of title
+    title: sample
+    version: 1.0.0 # line of version
+    # foot of info
+tags:
+    - pets
+    - store
+empty: [] # line of empty
+`
+
+func TestYAMLCommentedMapSlice(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should unmarshal comments attached to keys", func(t *testing.T) {
+		var data YAMLCommentedMapSlice
+		require.NoError(t, yaml.Unmarshal([]byte(commentedFixture), &data))
+		require.Len(t, data, 4)
+
+		assert.EqualT(t, YAMLComments{Head: "# head of openapi", Line: "# line of openapi"}, data[0].Comments)
+		assert.EqualT(t, YAMLComments{Head: "# head of info", Line: "# line of info"}, data[1].Comments)
+		assert.TrueT(t, data[2].Comments.IsZero())
+		assert.EqualT(t, YAMLComments{Line: "# line of empty"}, data[3].Comments)
+
+		info, ok := data[1].Value.(YAMLCommentedMapSlice)
+		require.TrueT(t, ok)
+		require.Len(t, info, 2)
+		assert.EqualT(t, YAMLComments{Head: "# head of title"}, info[0].Comments)
+		assert.EqualT(t, YAMLComments{Line: "# line of version", Foot: "# foot of info"}, info[1].Comments)
+	})
+
+	t.Run("should round trip YAML with comments", func(t *testing.T) {
+		var data YAMLCommentedMapSlice
+		require.NoError(t, yaml.Unmarshal([]byte(commentedFixture), &data))
+
+		b, err := yaml.Marshal(data)
+		require.NoError(t, err)
+		assert.EqualT(t, commentedFixture, string(b))
+	})
+
+	t.Run("should retain comments when editing values", func(t *testing.T) {
+		var data YAMLCommentedMapSlice
+		require.NoError(t, yaml.Unmarshal([]byte(commentedFixture), &data))
+
+		info, ok := data[1].Value.(YAMLCommentedMapSlice)
+		require.TrueT(t, ok)
+		info.SetOrderedItems(maps.All(map[string]any{"version": "1.1.0"}))
+		data[1].Value = info
+		data.SetOrderedItems(maps.All(map[string]any{"x-generated": true}))
+
+		b, err := yaml.Marshal(data)
+		require.NoError(t, err)
+		assert.EqualT(t, `# head of openapi
+openapi: 3.1.0 # line of openapi
+# head of info
+info: # line of info
+    # head of title
+    title: sample
+    version: 1.1.0 # line of version
+    # foot of info
+tags:
+    - pets
+    - store
+empty: [] # line of empty
+x-generated: true
+`, string(b))
+	})
+
+	t.Run("should retain document comments from a document node", func(t *testing.T) {
+		const input = "# license header\n\n# head of a\na: 1\n\n# trailer\n"
+		var doc yaml.Node
+		require.NoError(t, yaml.Unmarshal([]byte(input), &doc))
+
+		var data YAMLCommentedMapSlice
+		require.NoError(t, data.UnmarshalYAML(&doc))
+		require.Len(t, data, 1)
+		assert.EqualT(t, "# license header\n\n# head of a", data[0].Comments.Head)
+
+		b, err := yaml.Marshal(data)
+		require.NoError(t, err)
+		assert.StringContainsT(t, string(b), "# license header\n\n# head of a\na: 1\n")
+		assert.StringContainsT(t, string(b), "# trailer\n")
+	})
+
+	t.Run("should not unmarshal a document that is not an object", func(t *testing.T) {
+		var doc yaml.Node
+		require.NoError(t, yaml.Unmarshal([]byte("- a\n"), &doc))
+
+		var data YAMLCommentedMapSlice
+		require.ErrorIs(t, data.UnmarshalYAML(&doc), ErrYAML)
+	})
+
+	t.Run("should not unmarshal a sequence that is not an object", func(t *testing.T) {
+		var data YAMLCommentedMapSlice
+		err := yaml.Unmarshal([]byte("- a\n- b\n"), &data)
+		require.ErrorIs(t, err, ErrYAML)
+		assert.Nil(t, data)
+
+		var doc yaml.Node
+		require.NoError(t, yaml.Unmarshal([]byte("- a\n- b\n"), &doc))
+		require.ErrorIs(t, data.UnmarshalYAML(doc.Content[0]), ErrYAML)
+	})
+
+	t.Run("should round trip an empty object", func(t *testing.T) {
+		var data YAMLCommentedMapSlice
+		require.NoError(t, yaml.Unmarshal([]byte("{}"), &data))
+		require.NotNil(t, data)
+		assert.Empty(t, data)
+
+		j, err := json.Marshal(data)
+		require.NoError(t, err)
+		assert.EqualT(t, "{}", string(j))
+
+		var plain YAMLMapSlice
+		require.NoError(t, yaml.Unmarshal([]byte("{}"), &plain))
+		jj, err := json.Marshal(plain)
+		require.NoError(t, err)
+		assert.JSONEqBytes(t, jj, j)
+
+		b, err := yaml.Marshal(data)
+		require.NoError(t, err)
+		assert.EqualT(t, "{}\n", string(b))
+	})
+
+	t.Run("should report the position of errors", func(t *testing.T) {
+		var data YAMLCommentedMapSlice
+		err := yaml.Unmarshal([]byte("a:\n  b: !!int x\n"), &data)
+		require.ErrorIs(t, err, ErrYAML)
+
+		var perr *PositionError
+		require.TrueT(t, errors.As(err, &perr))
+		assert.EqualT(t, Position{Line: 2, Column: 6}, perr.Position)
+	})
+
+	t.Run("UnmarshalYAML of a nil node should yield a nil slice", func(t *testing.T) {
+		data := YAMLCommentedMapSlice{{Key: "a"}}
+		require.NoError(t, data.UnmarshalYAML(nil))
+		assert.Nil(t, data)
+	})
+
+	t.Run("should marshal a nil slice as null", func(t *testing.T) {
+		var data YAMLCommentedMapSlice
+
+		b, err := yaml.Marshal(data)
+		require.NoError(t, err)
+		assert.EqualT(t, "null\n", string(b))
+
+		j, err := json.Marshal(data)
+		require.NoError(t, err)
+		assert.EqualT(t, "null", string(j))
+	})
+
+	t.Run("should marshal JSON without comments", func(t *testing.T) {
+		var data YAMLCommentedMapSlice
+		require.NoError(t, yaml.Unmarshal([]byte(commentedFixture), &data))
+
+		j, err := json.Marshal(data)
+		require.NoError(t, err)
+		assert.EqualT(t,
+			`{"openapi":"3.1.0","info":{"title":"sample","version":"1.0.0"},"tags":["pets","store"],"empty":[]}`,
+			string(j),
+		)
+
+		t.Run("should unmarshal JSON", func(t *testing.T) {
+			var back YAMLCommentedMapSlice
+			require.NoError(t, json.Unmarshal(j, &back))
+			require.Len(t, back, 4)
+			assert.EqualT(t, "openapi", back[0].Key)
+			assert.TrueT(t, back[0].Comments.IsZero())
+
+			t.Run("should unmarshal JSON null", func(t *testing.T) {
+				require.NoError(t, json.Unmarshal([]byte("null"), &back))
+				assert.Nil(t, back)
+			})
+		})
+	})
+
+	t.Run("should iterate over ordered items", func(t *testing.T) {
+		data := YAMLCommentedMapSlice{{Key: "a", Value: 1}, {Key: "b", Value: 2}}
+
+		var keys []string
+		for k := range data.OrderedItems() {
+			keys = append(keys, k)
+		}
+		assert.Equal(t, []string{"a", "b"}, keys)
+
+		data.SetOrderedItems(nil)
+		assert.Nil(t, data)
+	})
+}
+
+func TestPlaceLineComment(t *testing.T) {
+	t.Parallel()
+
+	scalar := func(comment string) *yaml.Node {
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: "v", LineComment: comment}
+	}
+	sequence := func(style yaml.Style) *yaml.Node {
+		return &yaml.Node{Kind: yaml.SequenceNode, Style: style, Content: []*yaml.Node{scalar("")}}
+	}
+
+	t.Run("should move the comment to a scalar value", func(t *testing.T) {
+		key, value := scalar("# line"), scalar("")
+		placeLineComment(key, value)
+		assert.Empty(t, key.LineComment)
+		assert.EqualT(t, "# line", value.LineComment)
+	})
+
+	t.Run("should move the comment to a flow collection", func(t *testing.T) {
+		key, value := scalar("# line"), sequence(yaml.FlowStyle)
+		placeLineComment(key, value)
+		assert.Empty(t, key.LineComment)
+		assert.EqualT(t, "# line", value.LineComment)
+	})
+
+	t.Run("should keep the comment on the key of a block collection", func(t *testing.T) {
+		key, value := scalar("# line"), sequence(0)
+		placeLineComment(key, value)
+		assert.EqualT(t, "# line", key.LineComment)
+		assert.Empty(t, value.LineComment)
+	})
+
+	t.Run("should keep the comment of the value", func(t *testing.T) {
+		key, value := scalar("# key"), scalar("# value")
+		placeLineComment(key, value)
+		assert.EqualT(t, "# key", key.LineComment)
+		assert.EqualT(t, "# value", value.LineComment)
+	})
+}
@@ -4,8 +4,20 @@
 // Package yamlutils provides utilities to work with YAML documents.
 //
 //   - [BytesToYAMLDoc] to construct a [yaml.Node] document
+//   - [BytesToYAMLDocWith] to construct a [yaml.Node] document with options, e.g. to support non-object roots
 //   - [YAMLToJSON] to convert a [yaml.Node] document to JSON bytes
+//   - [YAMLToJSONWith] to convert a [yaml.Node] document to JSON bytes with options, e.g. to collect the [Positions] of values in the YAML source
+//   - [Documents] and [DocumentsToJSON] to iterate over a stream of YAML documents
+//   - [Encoder] to render JSON-compatible values as YAML, with options to control the layout
+//   - [JSONToYAML] to convert a JSON stream into YAML, without holding the document in memory
 //   - [YAMLMapSlice] to serialize and deserialize YAML with the order of keys maintained
+//   - [YAMLCommentedMapSlice] to serialize and deserialize YAML with the order of keys and the comments maintained
+//
+// The YAML utilities bound the nesting depth of documents and the expansion of aliases.
+// Stricter [Limits], e.g. for documents from untrusted sources, may be set with the [WithLimits] option.
+//
+// Scalars are resolved with the YAML 1.2 rules. Documents written for YAML 1.1 parsers may be converted
+// with the [WithYAML11] option, and [WithYAML11Warnings] reports the scalars that differ between both versions.
 package yamlutils
 
 import (
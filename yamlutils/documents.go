@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+import (
+	"bytes"
+	json "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/go-openapi/swag/jsonutils"
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// Documents iterates over a stream of YAML documents, separated by "---".
+//
+// Each document is yielded as a YAML document, i.e. a pointer to a [yaml.Node], like [BytesToYAMLDocWith] does.
+// Like [BytesToYAMLDocWith], only documents that are objects are supported, unless the [WithAllowAnyRoot] option is enabled.
+//
+// Empty documents (e.g. after a trailing "---" separator) are skipped.
+//
+// The iteration stops after the first error, which is yielded with a nil document.
+//
+// Anchors and aliases are not expanded at this stage: use [DocumentsToJSON] to convert the whole stream
+// with the same protections against deeply nested documents and excessive aliasing as [YAMLToJSON].
+//
+// The [WithLimits] option applies to the stream as a whole.
+func Documents(data []byte, opts ...Option) iter.Seq2[*yaml.Node, error] {
+	o := optionsWithDefaults(opts)
+
+	return func(yield func(*yaml.Node, error) bool) {
+		if err := o.limits.checkSize(data); err != nil {
+			yield(nil, err)
+
+			return
+		}
+
+		var count int
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+
+		for index := 0; ; index++ {
+			var document yaml.Node // preserve order that is present in the document
+			err := decoder.Decode(&document)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, fmt.Errorf("YAML document #%d: %w: %w", index, err, ErrYAML))
+
+				return
+			}
+
+			if isEmptyDocument(&document) {
+				continue
+			}
+
+			if err := o.checkRoot(&document); err != nil {
+				yield(nil, fmt.Errorf("YAML document #%d: %w", index, err))
+
+				return
+			}
+
+			if err := o.limits.check(&document, &count); err != nil {
+				yield(nil, fmt.Errorf("YAML document #%d: %w", index, err))
+
+				return
+			}
+
+			if !yield(&document, nil) {
+				return
+			}
+		}
+	}
+}
+
+// DocumentsToJSON iterates over a stream of YAML documents, separated by "---", and converts each document into JSON bytes.
+//
+// See [Documents].
+//
+// The limits on nesting depth and alias expansion that [YAMLToJSON] enforces on a single document
+// apply to the stream as a whole, so that a stream of many documents can't be used to work around them.
+func DocumentsToJSON(data []byte, opts ...Option) iter.Seq2[json.RawMessage, error] {
+	o := optionsWithDefaults(opts)
+
+	return func(yield func(json.RawMessage, error) bool) {
+		w := o.walker()   // shared by all documents in the stream
+		w.positions = nil // positions are only collected for a single document, see [YAMLToJSONWith]
+
+		for document, err := range Documents(data, opts...) {
+			if err != nil {
+				yield(nil, err)
+
+				return
+			}
+
+			jm, err := w.node(document, 0)
+			if err != nil {
+				yield(nil, err)
+
+				return
+			}
+
+			b, err := jsonutils.WriteJSON(jm)
+			if err != nil {
+				yield(nil, err)
+
+				return
+			}
+
+			if !yield(json.RawMessage(b), nil) {
+				return
+			}
+		}
+	}
+}
+
+// isEmptyDocument reports whether a YAML document holds no content, e.g. the document
+// that follows a trailing "---" separator.
+func isEmptyDocument(document *yaml.Node) bool {
+	if document.Kind == 0 {
+		return true
+	}
+
+	if document.Kind != yaml.DocumentNode || len(document.Content) != 1 {
+		return false
+	}
+
+	root := document.Content[0]
+
+	return root.Kind == yaml.ScalarNode && root.LongTag() == yamlNull && root.Value == ""
+}
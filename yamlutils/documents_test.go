@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+	yaml "go.yaml.in/yaml/v3"
+)
+
+func TestDocuments(t *testing.T) {
+	const stream = `---
+apiVersion: v1
+kind: Service
+---
+# an empty document
+---
+apiVersion: v1
+kind: Pod
+---
+`
+
+	t.Run("should iterate over documents", func(t *testing.T) {
+		var kinds []string
+		for document, err := range Documents([]byte(stream)) {
+			require.NoError(t, err)
+			require.EqualT(t, yaml.DocumentNode, document.Kind)
+
+			var doc YAMLMapSlice
+			require.NoError(t, document.Decode(&doc))
+			kinds = append(kinds, doc[1].Value.(string))
+		}
+
+		assert.Equal(t, []string{"Service", "Pod"}, kinds)
+	})
+
+	t.Run("should iterate over a single document", func(t *testing.T) {
+		var count int
+		for _, err := range Documents([]byte("a: 1\n")) {
+			require.NoError(t, err)
+			count++
+		}
+		assert.EqualT(t, 1, count)
+	})
+
+	t.Run("should iterate over an empty stream", func(t *testing.T) {
+		for range Documents(nil) {
+			t.Fatal("expected no document")
+		}
+	})
+
+	t.Run("should stop when the caller breaks", func(t *testing.T) {
+		var count int
+		for range Documents([]byte(stream)) {
+			count++
+
+			break
+		}
+		assert.EqualT(t, 1, count)
+	})
+
+	t.Run("should stop on the first invalid document", func(t *testing.T) {
+		for _, input := range []string{
+			"a: 1\n---\na: [\n---\nb: 2\n",
+			"a: 1\n---\n- 1\n- 2\n---\nb: 2\n",
+		} {
+			var (
+				count int
+				errs  []error
+			)
+			for document, err := range Documents([]byte(input)) {
+				if err != nil {
+					assert.Nil(t, document)
+					errs = append(errs, err)
+
+					continue
+				}
+				count++
+			}
+
+			assert.EqualT(t, 1, count)
+			require.Len(t, errs, 1)
+			require.ErrorIs(t, errs[0], ErrYAML)
+			assert.StringContainsT(t, errs[0].Error(), "#1")
+		}
+	})
+}
+
+func TestDocumentsToJSON(t *testing.T) {
+	t.Run("should convert documents to JSON", func(t *testing.T) {
+		var docs []string
+		for doc, err := range DocumentsToJSON([]byte("a: 1\n---\nb: &x [true]\nc: *x\n")) {
+			require.NoError(t, err)
+			docs = append(docs, string(doc))
+		}
+
+		assert.Equal(t, []string{`{"a":1}`, `{"b":[true],"c":[true]}`}, docs)
+	})
+
+	t.Run("should bound alias expansion across the whole stream", func(t *testing.T) {
+		// each document is accepted on its own, but the stream as a whole is large enough
+		// for the tolerated share of alias expansion to be lowered
+		single := aliasBomb(6, 3)
+		_, err := YAMLToJSON(mustYAMLDoc(t, single))
+		require.NoError(t, err)
+
+		var b strings.Builder
+		for range 200 {
+			b.WriteString("---\n")
+			b.Write(single)
+		}
+
+		var lastErr error
+		for _, err := range DocumentsToJSON([]byte(b.String())) {
+			if err != nil {
+				lastErr = err
+			}
+		}
+		require.Error(t, lastErr)
+		require.ErrorIs(t, lastErr, ErrYAML)
+		assert.StringContainsT(t, lastErr.Error(), "excessive aliasing")
+	})
+
+	t.Run("should bound the nesting depth of documents", func(t *testing.T) {
+		var lastErr error
+		for _, err := range DocumentsToJSON([]byte("a: 1\n---\nb: " + strings.Repeat("[", 10001) + strings.Repeat("]", 10001) + "\n")) {
+			lastErr = err
+		}
+		require.Error(t, lastErr)
+	})
+}
+
+func mustYAMLDoc(t *testing.T, data []byte) any {
+	t.Helper()
+
+	doc, err := BytesToYAMLDoc(data)
+	require.NoError(t, err)
+
+	return doc
+}
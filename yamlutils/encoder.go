@@ -0,0 +1,151 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+import (
+	"io"
+	"strings"
+
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// Quoting tells the [Encoder] which strings to render as quoted YAML scalars.
+type Quoting uint8
+
+const (
+	// QuoteWhenRequired only quotes strings that would otherwise be read as another type by a YAML 1.2 parser,
+	// e.g. "123", "true" or "null". This is the default.
+	QuoteWhenRequired Quoting = iota
+	// QuoteAmbiguous quotes strings that would otherwise be read as another type by a YAML 1.2 or YAML 1.1 parser,
+	// e.g. "yes", "on", "0777" or "1:20".
+	QuoteAmbiguous
+	// QuoteAlways quotes all string values. Keys are quoted as with [QuoteAmbiguous].
+	QuoteAlways
+)
+
+// Encoder writes JSON-compatible values as YAML documents to an output stream.
+//
+// Supported values are those produced by [YAMLToJSON] and the JSON utilities: [YAMLMapSlice], [YAMLCommentedMapSlice],
+// any type implementing [ifaces.Ordered], map[string]any, []any, strings, numbers, booleans and nil.
+//
+// The layout of the YAML output is configured with options such as [WithIndent], [WithFlowSequences],
+// [WithLiteralBlocks] and [WithQuoting].
+type Encoder struct {
+	encoder *yaml.Encoder
+	options
+}
+
+// NewEncoder builds an [Encoder] that writes to w.
+//
+// The [Encoder] must be closed after use, to flush any remaining data to w.
+func NewEncoder(w io.Writer, opts ...Option) *Encoder {
+	e := &Encoder{
+		encoder: yaml.NewEncoder(w),
+		options: optionsWithDefaults(opts),
+	}
+
+	if e.indent > 0 {
+		e.encoder.SetIndent(e.indent)
+	}
+
+	return e
+}
+
+// Encode writes the YAML rendering of value to the stream.
+//
+// When several values are encoded, the documents are separated by "---".
+func (e *Encoder) Encode(value any) error {
+	node, err := json2yaml(value, 0)
+	if err != nil {
+		return err
+	}
+
+	e.style(node, false)
+
+	return e.encoder.Encode(node)
+}
+
+// Close flushes any remaining data to the output stream.
+func (e *Encoder) Close() error {
+	return e.encoder.Close()
+}
+
+// style applies the layout options to a node tree produced by [json2yaml].
+func (e *Encoder) style(node *yaml.Node, isKey bool) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		e.styleScalar(node, isKey)
+
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			e.style(child, false)
+		}
+
+		if e.isFlowSequence(node) {
+			node.Style |= yaml.FlowStyle
+		}
+
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			e.style(keyNode, true)
+			e.style(valueNode, false)
+
+			// flow sequences may have turned a block value into a flow one
+			placeLineComment(keyNode, valueNode)
+		}
+	}
+}
+
+func (e *Encoder) styleScalar(node *yaml.Node, isKey bool) {
+	if node.Tag != yamlStringScalar {
+		return
+	}
+
+	if strings.Contains(node.Value, "\n") {
+		if !isKey && e.literalBlocks {
+			node.Style = yaml.LiteralStyle
+		} else {
+			node.Style = yaml.DoubleQuotedStyle
+		}
+
+		return
+	}
+
+	switch {
+	case e.quoting == QuoteAlways && !isKey:
+		node.Style = yaml.DoubleQuotedStyle
+	case e.quoting >= QuoteAmbiguous && isYAML11Ambiguous(node.Value):
+		node.Style = yaml.DoubleQuotedStyle
+	}
+}
+
+func (e *Encoder) isFlowSequence(node *yaml.Node) bool {
+	if len(node.Content) == 0 || len(node.Content) > e.flowMaxItems {
+		return false
+	}
+
+	for _, child := range node.Content {
+		if child.Kind != yaml.ScalarNode || child.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isYAML11Ambiguous reports whether a string would be read as another type (e.g. a boolean or a number)
+// by a YAML 1.1 parser.
+//
+// Strings that a YAML 1.2 parser would read as another type are always quoted by the YAML encoder.
+func isYAML11Ambiguous(value string) bool {
+	v, ok := resolveYAML11(value)
+	if !ok {
+		return true
+	}
+
+	_, isString := v.(string)
+
+	return !isString
+}
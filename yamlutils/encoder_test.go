@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+	yaml "go.yaml.in/yaml/v3"
+)
+
+func encodeYAML(t *testing.T, value any, opts ...Option) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf, opts...)
+	require.NoError(t, enc.Encode(value))
+	require.NoError(t, enc.Close())
+
+	return buf.String()
+}
+
+func TestEncoder(t *testing.T) {
+	t.Parallel()
+
+	value := YAMLMapSlice{
+		{Key: "description", Value: "first line\nsecond line\n"},
+		{Key: "tags", Value: []any{"pets", "store"}},
+		{Key: "codes", Value: []any{int64(200), int64(404), int64(500)}},
+		{Key: "nested", Value: YAMLMapSlice{
+			{Key: "enabled", Value: "on"},
+			{Key: "mode", Value: "0644"},
+			{Key: "count", Value: "12"},
+			{Key: "name", Value: "sample"},
+		}},
+		{Key: "200", Value: "ok"},
+	}
+
+	t.Run("should encode with default options", func(t *testing.T) {
+		assert.EqualT(t, `description: |
+    first line
+    second line
+tags:
+    - pets
+    - store
+codes:
+    - 200
+    - 404
+    - 500
+nested:
+    enabled: on
+    mode: "0644"
+    count: "12"
+    name: sample
+"200": ok
+`, encodeYAML(t, value))
+	})
+
+	t.Run("should encode with custom options", func(t *testing.T) {
+		actual := encodeYAML(t, value,
+			WithIndent(2),
+			WithFlowSequences(2),
+			WithLiteralBlocks(false),
+			WithQuoting(QuoteAmbiguous),
+		)
+
+		assert.EqualT(t, `description: "first line\nsecond line\n"
+tags: [pets, store]
+codes:
+  - 200
+  - 404
+  - 500
+nested:
+  enabled: "on"
+  mode: "0644"
+  count: "12"
+  name: sample
+"200": ok
+`, actual)
+
+		t.Run("should read back the same values", func(t *testing.T) {
+			var back YAMLMapSlice
+			require.NoError(t, yaml.Unmarshal([]byte(actual), &back))
+			assert.Equal(t, value, back)
+		})
+	})
+
+	t.Run("should quote all string values", func(t *testing.T) {
+		assert.EqualT(t, `a: "x"
+"on": [1, "y"]
+`, encodeYAML(t, YAMLMapSlice{
+			{Key: "a", Value: "x"},
+			{Key: "on", Value: []any{int64(1), "y"}},
+		}, WithQuoting(QuoteAlways), WithFlowSequences(5)))
+	})
+
+	t.Run("should keep line comments with flow sequences", func(t *testing.T) {
+		assert.EqualT(t, `# head
+tags: [a, b] # line
+`, encodeYAML(t, YAMLCommentedMapSlice{
+			{Key: "tags", Value: []any{"a", "b"}, Comments: YAMLComments{Head: "# head", Line: "# line"}},
+		}, WithFlowSequences(2)))
+	})
+
+	t.Run("should not render sequences of collections in flow style", func(t *testing.T) {
+		assert.EqualT(t, `- a: 1
+`, encodeYAML(t, []any{YAMLMapSlice{{Key: "a", Value: int64(1)}}}, WithFlowSequences(2)))
+	})
+
+	t.Run("should separate documents", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := NewEncoder(&buf)
+		require.NoError(t, enc.Encode(map[string]any{"a": 1}))
+		require.NoError(t, enc.Encode([]any{"b"}))
+		require.NoError(t, enc.Close())
+
+		assert.EqualT(t, "a: 1\n---\n- b\n", buf.String())
+	})
+
+	t.Run("should fail on unsupported values", func(t *testing.T) {
+		enc := NewEncoder(&bytes.Buffer{})
+		require.ErrorIs(t, enc.Encode(struct{}{}), ErrYAML)
+	})
+}
+
+func TestIsYAML11Ambiguous(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"y", "NO", "On", "off", "0777", "0b101", "0x1F", "1_000", "1:20", "190:20:30.15", "6.8523015e+5"} {
+		assert.TrueT(t, isYAML11Ambiguous(value), "expected %q to be ambiguous", value)
+	}
+
+	for _, value := range []string{"sample", "1.0.0", "yess", "0x", "12:60", "v1", "o"} {
+		assert.FalseT(t, isYAML11Ambiguous(value), "expected %q not to be ambiguous", value)
+	}
+}
@@ -3,6 +3,13 @@
 
 package yamlutils
 
+import (
+	"errors"
+	"fmt"
+
+	yaml "go.yaml.in/yaml/v3"
+)
+
 type yamlError string
 
 const (
@@ -13,3 +20,36 @@ const (
 func (e yamlError) Error() string {
 	return string(e)
 }
+
+// PositionError reports the position in the YAML source of an error raised while
+// converting a YAML document.
+//
+// Use [errors.As] to retrieve the position from an error returned by [YAMLToJSON].
+type PositionError struct {
+	Position
+
+	Err error
+}
+
+func (e *PositionError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Position, e.Err)
+}
+
+func (e *PositionError) Unwrap() error {
+	return e.Err
+}
+
+// withPosition attaches the position of a YAML node to an error, unless the error
+// already carries a (more accurate) position or the node has no known position.
+func withPosition(node *yaml.Node, err error) error {
+	if node == nil || node.Line == 0 {
+		return err
+	}
+
+	var perr *PositionError
+	if errors.As(err, &perr) {
+		return err
+	}
+
+	return &PositionError{Position: Position{Line: node.Line, Column: node.Column}, Err: err}
+}
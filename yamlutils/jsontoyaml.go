@@ -0,0 +1,358 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+import (
+	"bufio"
+	"bytes"
+	json "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/go-openapi/swag/jsonutils"
+)
+
+const (
+	defaultIndent = 4 // like the YAML encoder
+
+	// maxImplicitKeyLength is the maximum length of an implicit YAML key. Longer keys must be explicit, i.e. "? key".
+	maxImplicitKeyLength = 1024
+)
+
+var (
+	// plainYAMLString matches the strings that may safely be rendered as a plain (unquoted) YAML scalar,
+	// unless they would be read as another type, e.g. a boolean or a number.
+	//
+	// This is a conservative subset: other strings are rendered as double-quoted scalars.
+	plainYAMLString = regexp.MustCompile(`^([A-Za-z_/][A-Za-z0-9_./ -]*|[0-9][A-Za-z0-9_./]*)$`)
+
+	// yaml12Number matches the numbers in YAML 1.2 notations.
+	yaml12Number = regexp.MustCompile(`^[-+]?(0o[0-7]+|0x[0-9a-fA-F]+|(\.[0-9]+|[0-9][0-9_]*(\.[0-9_]*)?)([eE][-+]?[0-9]+)?)$`)
+)
+
+// JSONToYAML converts a JSON document read from r into a YAML document written to w, with the order of keys maintained.
+//
+// Unlike converting JSON to YAML with [YAMLMapSlice] or the [Encoder], the JSON document is never held in memory:
+// JSON tokens are read from r and the YAML document is written to w in the block style, as they come.
+//
+// The JSON tokens are read with a [jsonutils.Decoder], i.e. by the registered JSON adapter that supports
+// tokenizing JSON, with the limit on the nesting depth of this adapter.
+//
+// The [WithIndent] and [WithLimits] options apply. Only the MaxDepth, MaxNodes and MaxBytes limits are relevant.
+func JSONToYAML(w io.Writer, r io.Reader, opts ...Option) error {
+	o := optionsWithDefaults(opts)
+
+	if o.limits.MaxBytes > 0 {
+		r = &limitedReader{r: r, limit: o.limits.MaxBytes, remaining: o.limits.MaxBytes}
+	}
+
+	c := &jsonToYAML{
+		dec:    jsonutils.NewDecoder(r),
+		w:      bufio.NewWriter(w),
+		indent: o.indent,
+		limits: o.limits,
+	}
+	defer func() {
+		_ = c.dec.Close()
+	}()
+
+	if c.indent <= 0 {
+		c.indent = defaultIndent
+	}
+
+	if err := c.document(); err != nil {
+		return fmt.Errorf("unable to convert JSON to YAML: %w: %w", err, ErrYAML)
+	}
+
+	return c.w.Flush()
+}
+
+// jsonToYAML converts a stream of JSON tokens into block YAML.
+type jsonToYAML struct {
+	dec     *jsonutils.Decoder
+	w       *bufio.Writer
+	indent  int
+	limits  Limits
+	nodes   int
+	peeked  jsonutils.Token
+	hasPeek bool
+	scratch bytes.Buffer
+}
+
+func (c *jsonToYAML) next() (jsonutils.Token, error) {
+	if c.hasPeek {
+		c.hasPeek = false
+
+		return c.peeked, nil
+	}
+
+	tok, err := c.dec.Next()
+	if errors.Is(err, io.EOF) {
+		return tok, io.ErrUnexpectedEOF
+	}
+
+	return tok, err
+}
+
+func (c *jsonToYAML) peek() (jsonutils.Token, error) {
+	if !c.hasPeek {
+		tok, err := c.next()
+		if err != nil {
+			return tok, err
+		}
+
+		c.peeked, c.hasPeek = tok, true
+	}
+
+	return c.peeked, nil
+}
+
+// isEmpty consumes the closing delimiter of an empty object or array.
+func (c *jsonToYAML) isEmpty(closing jsonutils.TokenKind) (bool, error) {
+	tok, err := c.peek()
+	if err != nil {
+		return false, err
+	}
+
+	if tok.Kind != closing {
+		return false, nil
+	}
+
+	c.hasPeek = false
+
+	return true, nil
+}
+
+func (c *jsonToYAML) document() error {
+	tok, err := c.next()
+	if err != nil {
+		return err
+	}
+
+	if err := c.value(tok, 0, 0, atRoot); err != nil {
+		return err
+	}
+
+	if _, err := c.dec.Next(); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return err
+		}
+
+		return errors.New("unexpected data after the JSON document")
+	}
+
+	return nil
+}
+
+// position tells where a JSON value is written in the YAML output.
+type position uint8
+
+const (
+	atRoot    position = iota
+	afterKey           // after "key:"
+	afterDash          // after "-", in a sequence
+)
+
+// value writes a JSON value at the current position, followed by a new line.
+//
+// col is the indentation of the content of the value, if this is a non-empty collection.
+func (c *jsonToYAML) value(tok jsonutils.Token, col, depth int, pos position) error {
+	c.nodes++
+	if c.limits.MaxNodes > 0 && c.nodes > c.limits.MaxNodes {
+		return maxNodesError(c.limits.MaxNodes)
+	}
+
+	var closing jsonutils.TokenKind
+	switch tok.Kind { //nolint:exhaustive // other tokens are scalars or unexpected
+	case jsonutils.TokenBeginObject:
+		closing = jsonutils.TokenEndObject
+	case jsonutils.TokenBeginArray:
+		closing = jsonutils.TokenEndArray
+	case jsonutils.TokenString, jsonutils.TokenNumber, jsonutils.TokenBool, jsonutils.TokenNull:
+		c.space(pos)
+		c.scalar(tok)
+		c.w.WriteByte('\n')
+
+		return nil
+	default:
+		return fmt.Errorf("unexpected JSON token %v", tok)
+	}
+
+	if depth >= c.limits.maxDepth() {
+		return maxNestingDepthError(c.limits.maxDepth())
+	}
+
+	empty, err := c.isEmpty(closing)
+	if err != nil {
+		return err
+	}
+
+	if empty {
+		c.space(pos)
+		if closing == jsonutils.TokenEndObject {
+			c.w.WriteString("{}")
+		} else {
+			c.w.WriteString("[]")
+		}
+		c.w.WriteByte('\n')
+
+		return nil
+	}
+
+	// after "- ", the first item of a collection is written on the same line
+	inline := pos == afterDash
+	if pos == afterKey {
+		c.w.WriteByte('\n')
+	} else {
+		c.space(pos)
+	}
+
+	if tok.Kind == jsonutils.TokenBeginObject {
+		return c.object(col, depth+1, inline)
+	}
+
+	return c.array(col, depth+1, inline)
+}
+
+// object writes the keys of a non-empty JSON object, indented at col.
+//
+// When inline is true, the first key is written on the current line.
+func (c *jsonToYAML) object(col, depth int, inline bool) error {
+	for first := true; ; first = false {
+		tok, err := c.next()
+		if err != nil {
+			return err
+		}
+
+		if tok.Kind == jsonutils.TokenEndObject {
+			return nil
+		}
+
+		if tok.Kind != jsonutils.TokenKey {
+			return fmt.Errorf("expected a JSON object key but got %v", tok)
+		}
+		key := tok.Text
+
+		if !first || !inline {
+			c.writeIndent(col)
+		}
+
+		rendered := c.renderString(key)
+		if len(rendered) > maxImplicitKeyLength {
+			c.w.WriteString("? ")
+			c.w.WriteString(rendered)
+			c.w.WriteByte('\n')
+			c.writeIndent(col)
+		} else {
+			c.w.WriteString(rendered)
+		}
+		c.w.WriteByte(':')
+
+		tok, err = c.next()
+		if err != nil {
+			return err
+		}
+
+		if err := c.value(tok, col+c.indent, depth, afterKey); err != nil {
+			return err
+		}
+	}
+}
+
+// array writes the items of a non-empty JSON array, indented at col.
+//
+// When inline is true, the first item is written on the current line.
+func (c *jsonToYAML) array(col, depth int, inline bool) error {
+	for first := true; ; first = false {
+		tok, err := c.next()
+		if err != nil {
+			return err
+		}
+
+		if tok.Kind == jsonutils.TokenEndArray {
+			return nil
+		}
+
+		if !first || !inline {
+			c.writeIndent(col)
+		}
+		c.w.WriteByte('-')
+
+		// the content of an item is aligned after "- "
+		if err := c.value(tok, col+len("- "), depth, afterDash); err != nil {
+			return err
+		}
+	}
+}
+
+// space separates a value from the preceding "key:" or "-".
+func (c *jsonToYAML) space(pos position) {
+	if pos != atRoot {
+		c.w.WriteByte(' ')
+	}
+}
+
+func (c *jsonToYAML) writeIndent(col int) {
+	for range col {
+		c.w.WriteByte(' ')
+	}
+}
+
+func (c *jsonToYAML) scalar(tok jsonutils.Token) {
+	if tok.Kind == jsonutils.TokenString {
+		c.w.WriteString(c.renderString(tok.Text))
+
+		return
+	}
+
+	// the literal of a number, a boolean or null is the same in YAML
+	c.w.WriteString(tok.Text)
+}
+
+// renderString renders a string as a YAML scalar: plain if this is safe, or double-quoted otherwise.
+//
+// A JSON string is a valid YAML double-quoted scalar.
+func (c *jsonToYAML) renderString(value string) string {
+	if plainYAMLString.MatchString(value) && !strings.HasSuffix(value, " ") &&
+		!yaml12Number.MatchString(value) && !isYAML11Ambiguous(value) {
+		return value
+	}
+
+	c.scratch.Reset()
+	enc := json.NewEncoder(&c.scratch)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(value) // a string always encodes
+
+	return strings.TrimSuffix(c.scratch.String(), "\n")
+}
+
+// limitedReader fails when more than the allowed number of bytes are read.
+type limitedReader struct {
+	r         io.Reader
+	limit     int
+	remaining int
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		var probe [1]byte
+		if n, _ := l.r.Read(probe[:]); n > 0 {
+			return 0, fmt.Errorf("JSON document exceeds the maximum size of %d bytes", l.limit)
+		}
+
+		return 0, io.EOF
+	}
+
+	if len(p) > l.remaining {
+		p = p[:l.remaining]
+	}
+
+	n, err := l.r.Read(p)
+	l.remaining -= n
+
+	return n, err
+}
@@ -0,0 +1,197 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/swag/jsonutils/adapters"
+	stdlib "github.com/go-openapi/swag/jsonutils/adapters/stdlib/json"
+	fixtures "github.com/go-openapi/swag/jsonutils/fixtures_test"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestJSONToYAML(t *testing.T) {
+	t.Parallel()
+
+	convert := func(t *testing.T, input string, opts ...Option) (string, error) {
+		t.Helper()
+
+		var buf bytes.Buffer
+		err := JSONToYAML(&buf, strings.NewReader(input), opts...)
+
+		return buf.String(), err
+	}
+
+	t.Run("should convert JSON to block YAML, with the order of keys maintained", func(t *testing.T) {
+		const input = `{
+			"swagger": "2.0",
+			"info": {"title": "sample API", "version": "1.0.0", "x-enabled": "yes"},
+			"tags": ["pets", "store", {"name": "users", "x-order": 3}],
+			"matrix": [[1, 2], [], {}],
+			"empty": {},
+			"nothing": null,
+			"flag": false,
+			"ratio": 1.5e-3,
+			"200": "ok",
+			"key: with colon": "multi\nline",
+			"unicode": "<é>"
+		}`
+
+		actual, err := convert(t, input)
+		require.NoError(t, err)
+		assert.EqualT(t, `swagger: "2.0"
+info:
+    title: sample API
+    version: 1.0.0
+    x-enabled: "yes"
+tags:
+    - pets
+    - store
+    - name: users
+      x-order: 3
+matrix:
+    - - 1
+      - 2
+    - []
+    - {}
+empty: {}
+nothing: null
+flag: false
+ratio: 1.5e-3
+"200": ok
+"key: with colon": "multi\nline"
+unicode: "<é>"
+`, actual)
+
+		t.Run("should read back the same JSON", func(t *testing.T) {
+			doc, err := BytesToYAMLDoc([]byte(actual))
+			require.NoError(t, err)
+
+			back, err := YAMLToJSON(doc)
+			require.NoError(t, err)
+			fixtures.JSONEqualOrderedBytes(t, []byte(input), back)
+		})
+	})
+
+	t.Run("should convert with a custom indentation", func(t *testing.T) {
+		actual, err := convert(t, `{"a":{"b":[1]}}`, WithIndent(2))
+		require.NoError(t, err)
+		assert.EqualT(t, "a:\n  b:\n    - 1\n", actual)
+	})
+
+	t.Run("should convert non-object roots", func(t *testing.T) {
+		for input, expected := range map[string]string{
+			`"x"`:         "x\n",
+			`12`:          "12\n",
+			`[]`:          "[]\n",
+			`{}`:          "{}\n",
+			`[{"a":1},2]`: "- a: 1\n- 2\n",
+		} {
+			actual, err := convert(t, input)
+			require.NoError(t, err)
+			assert.EqualT(t, expected, actual)
+		}
+	})
+
+	t.Run("should use an explicit key for long keys", func(t *testing.T) {
+		key := strings.Repeat("k", maxImplicitKeyLength+1)
+		actual, err := convert(t, `{"`+key+`":1}`)
+		require.NoError(t, err)
+		assert.EqualT(t, "? "+key+"\n: 1\n", actual)
+
+		doc, err := BytesToYAMLDoc([]byte(actual))
+		require.NoError(t, err)
+		back, err := YAMLToJSON(doc)
+		require.NoError(t, err)
+		assert.JSONEqBytes(t, []byte(`{"`+key+`":1}`), back)
+	})
+
+	t.Run("should quote strings that would be read as another type", func(t *testing.T) {
+		var c jsonToYAML
+
+		for value, expected := range map[string]string{
+			"sample":     "sample",
+			"a/b.c d-e":  "a/b.c d-e",
+			"1.0.0":      "1.0.0",
+			"v1":         "v1",
+			"":           `""`,
+			"true":       `"true"`,
+			"Off":        `"Off"`,
+			"~":          `"~"`,
+			"12":         `"12"`,
+			"1e3":        `"1e3"`,
+			"0o17":       `"0o17"`,
+			"1_000":      `"1_000"`,
+			"2001-12-14": `"2001-12-14"`,
+			"a: b":       `"a: b"`,
+			"a #b":       `"a #b"`,
+			"trailing ":  `"trailing "`,
+			"-x":         `"-x"`,
+		} {
+			assert.EqualT(t, expected, c.renderString(value), "unexpected rendering for %q", value)
+		}
+	})
+
+	t.Run("should fail on invalid JSON", func(t *testing.T) {
+		for _, input := range []string{``, `{"a":`, `{"a":1}}`, `{"a":1} 2`, `[1,]`} {
+			_, err := convert(t, input)
+			require.ErrorIs(t, err, ErrYAML, "expected an error for %q", input)
+		}
+	})
+
+	t.Run("should enforce limits", func(t *testing.T) {
+		_, err := convert(t, `{"a":{"b":{"c":1}}}`, WithLimits(Limits{MaxDepth: 2}))
+		require.ErrorIs(t, err, ErrYAML)
+		assert.StringContainsT(t, err.Error(), "maximum nesting depth of 2 exceeded")
+
+		_, err = convert(t, `[1,2,3,4]`, WithLimits(Limits{MaxNodes: 4}))
+		require.ErrorIs(t, err, ErrYAML)
+
+		_, err = convert(t, `[1,2,3,4]`, WithLimits(Limits{MaxBytes: 5}))
+		require.ErrorIs(t, err, ErrYAML)
+		assert.StringContainsT(t, err.Error(), "maximum size of 5 bytes")
+
+		_, err = convert(t, `[1,2,3,4]`, WithLimits(Limits{MaxDepth: 1, MaxNodes: 5, MaxBytes: 9}))
+		require.NoError(t, err)
+	})
+
+	t.Run("should convert all test fixtures", func(t *testing.T) {
+		harness := fixtures.NewHarness(t)
+		harness.Init()
+
+		for name, fixture := range harness.AllTests(fixtures.WithoutError(true)) {
+			t.Run(name, func(t *testing.T) {
+				actual, err := convert(t, fixture.JSONPayload)
+				require.NoError(t, err)
+
+				doc, err := BytesToYAMLDocWith([]byte(actual), WithAllowAnyRoot(true))
+				require.NoError(t, err)
+
+				back, err := YAMLToJSON(doc)
+				require.NoError(t, err)
+				fixtures.JSONEqualOrderedBytes(t, fixture.JSONBytes(), back)
+			})
+		}
+	})
+}
+
+func TestJSONToYAMLWithAdapter(t *testing.T) {
+	// not parallel: this test registers a JSON adapter globally
+	adapters.Registry.Reset()
+	stdlib.Register(adapters.Registry, stdlib.WithMaxNestingDepth(2))
+	t.Cleanup(adapters.Registry.Reset)
+
+	var buf bytes.Buffer
+	require.NoError(t, JSONToYAML(&buf, strings.NewReader(`{"a":[1]}`)))
+	assert.EqualT(t, "a:\n    - 1\n", buf.String())
+
+	buf.Reset()
+	err := JSONToYAML(&buf, strings.NewReader(`{"a":[[1]]}`))
+	require.ErrorIs(t, err, ErrYAML)
+	assert.StringContainsT(t, err.Error(), "maximum nesting depth")
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+import (
+	"fmt"
+
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// Limits bounds the resources spent on a YAML document, e.g. when processing documents
+// from untrusted sources.
+//
+// A zero value for a field means the default for this limit.
+// Limits apply to [BytesToYAMLDocWith], [YAMLToJSONWith], [Documents], [DocumentsToJSON], [JSONToYAML],
+// [YAMLMapSlice.UnmarshalYAMLWith] and [YAMLCommentedMapSlice.UnmarshalYAMLWith].
+//
+// Limits that depend on the expansion of aliases (MaxNodes and MaxAliasExpansions) are
+// only fully enforced when the document is converted, as the parsing step keeps aliases unexpanded.
+type Limits struct {
+	// MaxDepth is the maximum nesting depth of a document.
+	//
+	// It defaults to 10000, which is also the limit enforced by the YAML parser.
+	MaxDepth int
+
+	// MaxNodes is the maximum number of YAML nodes in a document, including the nodes expanded from aliases.
+	//
+	// By default, the number of nodes is not limited.
+	MaxNodes int
+
+	// MaxBytes is the maximum size in bytes of the YAML input.
+	//
+	// It is only enforced by the entry points that read the input: [BytesToYAMLDocWith], [Documents],
+	// [DocumentsToJSON] and [JSONToYAML]. It is ignored by the entry points that receive an already parsed
+	// document: [YAMLToJSONWith], [YAMLMapSlice.UnmarshalYAMLWith] and [YAMLCommentedMapSlice.UnmarshalYAMLWith].
+	//
+	// By default, the size of the input is not limited.
+	MaxBytes int
+
+	// MaxAliasExpansions is the maximum number of YAML nodes expanded from aliases.
+	//
+	// By default, the expansion of aliases is only limited in proportion to the size of the document,
+	// like the YAML decoder does to reject "alias bombs". This proportional limit always applies.
+	MaxAliasExpansions int
+
+	// DisableAliases rejects documents that contain anchors or aliases.
+	DisableAliases bool
+}
+
+// WithLimits sets the limits enforced when parsing or converting YAML documents.
+//
+// By default, only the maximum nesting depth and the proportional limit on the expansion of aliases are enforced.
+func WithLimits(limits Limits) Option {
+	return func(o *options) {
+		o.limits = limits
+	}
+}
+
+func (l Limits) maxDepth() int {
+	if l.MaxDepth <= 0 {
+		return defaultMaxNestingDepth
+	}
+
+	return l.MaxDepth
+}
+
+// checkSize verifies the size of the YAML input.
+func (l Limits) checkSize(data []byte) error {
+	if l.MaxBytes > 0 && len(data) > l.MaxBytes {
+		return fmt.Errorf("YAML document exceeds the maximum size of %d bytes: %w", l.MaxBytes, ErrYAML)
+	}
+
+	return nil
+}
+
+// check verifies the limits that can be enforced on a parsed YAML document, before aliases are expanded.
+//
+// The count of nodes accumulates across calls, so a stream of documents is checked as a whole.
+func (l Limits) check(document *yaml.Node, count *int) error {
+	if l.MaxDepth <= 0 && l.MaxNodes <= 0 && !l.DisableAliases {
+		return nil // the parser already enforces the default depth
+	}
+
+	return l.checkNode(document, 0, count)
+}
+
+func (l Limits) checkNode(node *yaml.Node, depth int, count *int) error {
+	if depth > l.maxDepth() {
+		return withPosition(node, maxNestingDepthError(l.maxDepth()))
+	}
+
+	*count++
+	if l.MaxNodes > 0 && *count > l.MaxNodes {
+		return withPosition(node, maxNodesError(l.MaxNodes))
+	}
+
+	if l.DisableAliases && (node.Anchor != "" || node.Kind == yaml.AliasNode) {
+		return withPosition(node, errAliasesDisabled)
+	}
+
+	for _, child := range node.Content {
+		if err := l.checkNode(child, depth+1, count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func maxNestingDepthError(depth int) error {
+	return fmt.Errorf("maximum nesting depth of %d exceeded: %w", depth, ErrYAML)
+}
+
+func maxNodesError(nodes int) error {
+	return fmt.Errorf("maximum number of %d YAML nodes exceeded: %w", nodes, ErrYAML)
+}
+
+func maxAliasExpansionsError(nodes int) error {
+	return fmt.Errorf("maximum number of %d YAML nodes expanded from aliases exceeded: %w", nodes, ErrYAML)
+}
@@ -0,0 +1,304 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+	yaml "go.yaml.in/yaml/v3"
+)
+
+func TestLimits(t *testing.T) {
+	t.Parallel()
+
+	const nested = "a:\n  b:\n    c:\n      d: 1\n"
+
+	t.Run("with MaxDepth", func(t *testing.T) {
+		limits := WithLimits(Limits{MaxDepth: 4})
+
+		t.Run("should reject a deeply nested document when parsing", func(t *testing.T) {
+			_, err := BytesToYAMLDocWith([]byte(nested), limits)
+			require.ErrorIs(t, err, ErrYAML)
+			assert.StringContainsT(t, err.Error(), "maximum nesting depth of 4 exceeded")
+
+			var perr *PositionError
+			require.TrueT(t, errors.As(err, &perr))
+			assert.EqualT(t, 4, perr.Line)
+		})
+
+		t.Run("should reject a deeply nested document when converting", func(t *testing.T) {
+			doc, err := BytesToYAMLDoc([]byte(nested))
+			require.NoError(t, err)
+
+			_, err = YAMLToJSONWith(doc, limits)
+			require.ErrorIs(t, err, ErrYAML)
+			assert.StringContainsT(t, err.Error(), "maximum nesting depth of 4 exceeded")
+		})
+
+		t.Run("should reject a deeply nested document when unmarshaling", func(t *testing.T) {
+			var doc yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(nested), &doc))
+
+			var data YAMLMapSlice
+			err := data.UnmarshalYAMLWith(doc.Content[0], WithLimits(Limits{MaxDepth: 3}))
+			require.ErrorIs(t, err, ErrYAML)
+			assert.StringContainsT(t, err.Error(), "maximum nesting depth of 3 exceeded")
+
+			var commented YAMLCommentedMapSlice
+			err = commented.UnmarshalYAMLWith(&doc, limits)
+			require.ErrorIs(t, err, ErrYAML)
+		})
+
+		t.Run("should accept a document within limits", func(t *testing.T) {
+			doc, err := BytesToYAMLDocWith([]byte(nested), WithLimits(Limits{MaxDepth: 5}))
+			require.NoError(t, err)
+
+			d, err := YAMLToJSONWith(doc, WithLimits(Limits{MaxDepth: 5}))
+			require.NoError(t, err)
+			assert.JSONEqBytes(t, []byte(`{"a":{"b":{"c":{"d":1}}}}`), d)
+		})
+	})
+
+	t.Run("with MaxNodes", func(t *testing.T) {
+		const input = "a: &x [1, 2, 3]\nb: *x\n"
+		doc, err := BytesToYAMLDocWith([]byte(input), WithLimits(Limits{MaxNodes: 10}))
+		require.NoError(t, err, "aliases are not expanded when parsing")
+
+		t.Run("should count nodes expanded from aliases when converting", func(t *testing.T) {
+			_, err := YAMLToJSONWith(doc, WithLimits(Limits{MaxNodes: 10}))
+			require.ErrorIs(t, err, ErrYAML)
+			assert.StringContainsT(t, err.Error(), "maximum number of 10 YAML nodes exceeded")
+
+			_, err = YAMLToJSONWith(doc, WithLimits(Limits{MaxNodes: 20}))
+			require.NoError(t, err)
+		})
+
+		t.Run("should reject a large document when parsing", func(t *testing.T) {
+			_, err := BytesToYAMLDocWith([]byte(input), WithLimits(Limits{MaxNodes: 5}))
+			require.ErrorIs(t, err, ErrYAML)
+		})
+
+		t.Run("should count nodes across a stream of documents", func(t *testing.T) {
+			const stream = "a: 1\n---\nb: 2\n---\nc: 3\n"
+
+			var count int
+			var err error
+			for _, err = range Documents([]byte(stream), WithLimits(Limits{MaxNodes: 8})) {
+				if err != nil {
+					break
+				}
+				count++
+			}
+			require.ErrorIs(t, err, ErrYAML)
+			assert.EqualT(t, 2, count)
+
+			count = 0
+			for _, err = range DocumentsToJSON([]byte(stream), WithLimits(Limits{MaxNodes: 8})) {
+				if err != nil {
+					break
+				}
+				count++
+			}
+			require.ErrorIs(t, err, ErrYAML)
+			assert.EqualT(t, 2, count)
+		})
+	})
+
+	t.Run("with MaxBytes", func(t *testing.T) {
+		_, err := BytesToYAMLDocWith([]byte(nested), WithLimits(Limits{MaxBytes: 10}))
+		require.ErrorIs(t, err, ErrYAML)
+		assert.StringContainsT(t, err.Error(), "maximum size of 10 bytes")
+
+		for _, err := range Documents([]byte(nested), WithLimits(Limits{MaxBytes: 10})) {
+			require.ErrorIs(t, err, ErrYAML)
+		}
+
+		_, err = BytesToYAMLDocWith([]byte(nested), WithLimits(Limits{MaxBytes: len(nested)}))
+		require.NoError(t, err)
+	})
+
+	t.Run("with MaxAliasExpansions", func(t *testing.T) {
+		doc, err := BytesToYAMLDoc(aliasBomb(3, 3))
+		require.NoError(t, err)
+
+		_, err = YAMLToJSONWith(doc, WithLimits(Limits{MaxAliasExpansions: 20}))
+		require.ErrorIs(t, err, ErrYAML)
+		assert.StringContainsT(t, err.Error(), "maximum number of 20 YAML nodes expanded from aliases exceeded")
+
+		_, err = YAMLToJSONWith(doc)
+		require.NoError(t, err)
+	})
+
+	t.Run("with DisableAliases", func(t *testing.T) {
+		limits := WithLimits(Limits{DisableAliases: true})
+
+		t.Run("should reject anchors when parsing", func(t *testing.T) {
+			_, err := BytesToYAMLDocWith([]byte("a: &x 1\n"), limits)
+			require.ErrorIs(t, err, ErrYAML)
+			assert.StringContainsT(t, err.Error(), "anchors and aliases are not allowed")
+		})
+
+		t.Run("should reject aliases when converting", func(t *testing.T) {
+			doc, err := BytesToYAMLDoc([]byte("a: &x 1\nb: *x\n"))
+			require.NoError(t, err)
+
+			_, err = YAMLToJSONWith(doc, limits)
+			require.ErrorIs(t, err, ErrYAML)
+
+			var perr *PositionError
+			require.TrueT(t, errors.As(err, &perr))
+			assert.EqualT(t, Position{Line: 1, Column: 4}, perr.Position)
+		})
+
+		t.Run("should reject aliases when unmarshaling", func(t *testing.T) {
+			var doc yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte("a: &x 1\nb: *x\n"), &doc))
+
+			var data YAMLMapSlice
+			require.ErrorIs(t, data.UnmarshalYAMLWith(doc.Content[0], limits), ErrYAML)
+		})
+
+		t.Run("should accept documents without aliases", func(t *testing.T) {
+			doc, err := BytesToYAMLDocWith([]byte("a: 1\n"), limits)
+			require.NoError(t, err)
+
+			_, err = YAMLToJSONWith(doc, limits)
+			require.NoError(t, err)
+		})
+	})
+}
+
+func TestLimitsEntryPoints(t *testing.T) {
+	t.Parallel()
+
+	const nested = `{"a":{"b":{"c":{"d":1}}}}`
+
+	// entry points that receive raw input, or an already parsed document
+	entryPoints := map[string]func([]byte, ...Option) error{
+		"BytesToYAMLDocWith": func(data []byte, opts ...Option) error {
+			_, err := BytesToYAMLDocWith(data, opts...)
+
+			return err
+		},
+		"DocumentsToJSON": func(data []byte, opts ...Option) error {
+			for _, err := range DocumentsToJSON(data, opts...) {
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		"JSONToYAML": func(data []byte, opts ...Option) error {
+			var buf bytes.Buffer
+
+			return JSONToYAML(&buf, bytes.NewReader(data), opts...)
+		},
+		"YAMLToJSONWith": func(data []byte, opts ...Option) error {
+			doc, err := BytesToYAMLDoc(data)
+			if err != nil {
+				return err
+			}
+
+			_, err = YAMLToJSONWith(doc, opts...)
+
+			return err
+		},
+		"YAMLMapSlice.UnmarshalYAMLWith": func(data []byte, opts ...Option) error {
+			var doc yaml.Node
+			if err := yaml.Unmarshal(data, &doc); err != nil {
+				return err
+			}
+
+			var m YAMLMapSlice
+
+			return m.UnmarshalYAMLWith(doc.Content[0], opts...)
+		},
+		"YAMLCommentedMapSlice.UnmarshalYAMLWith": func(data []byte, opts ...Option) error {
+			var doc yaml.Node
+			if err := yaml.Unmarshal(data, &doc); err != nil {
+				return err
+			}
+
+			var m YAMLCommentedMapSlice
+
+			return m.UnmarshalYAMLWith(&doc, opts...)
+		},
+	}
+
+	for _, test := range []struct {
+		name     string
+		input    []byte
+		limits   Limits
+		enforced []string // JSONToYAML only applies to JSON input
+	}{
+		{
+			name:     "MaxBytes",
+			input:    []byte(nested),
+			limits:   Limits{MaxBytes: 10},
+			enforced: []string{"BytesToYAMLDocWith", "DocumentsToJSON", "JSONToYAML"},
+		},
+		{
+			name:   "MaxDepth",
+			input:  []byte(nested),
+			limits: Limits{MaxDepth: 3},
+			enforced: []string{
+				"BytesToYAMLDocWith", "DocumentsToJSON", "JSONToYAML", "YAMLToJSONWith",
+				"YAMLMapSlice.UnmarshalYAMLWith", "YAMLCommentedMapSlice.UnmarshalYAMLWith",
+			},
+		},
+		{
+			name:   "MaxNodes",
+			input:  []byte(nested),
+			limits: Limits{MaxNodes: 4},
+			enforced: []string{
+				"BytesToYAMLDocWith", "DocumentsToJSON", "JSONToYAML", "YAMLToJSONWith",
+				"YAMLMapSlice.UnmarshalYAMLWith", "YAMLCommentedMapSlice.UnmarshalYAMLWith",
+			},
+		},
+		{
+			name:   "MaxAliasExpansions",
+			input:  aliasBomb(3, 3),
+			limits: Limits{MaxAliasExpansions: 20},
+			enforced: []string{
+				"DocumentsToJSON", "YAMLToJSONWith",
+				"YAMLMapSlice.UnmarshalYAMLWith", "YAMLCommentedMapSlice.UnmarshalYAMLWith",
+			},
+		},
+		{
+			name:   "DisableAliases",
+			input:  []byte("a: &x 1\nb: *x\n"),
+			limits: Limits{DisableAliases: true},
+			enforced: []string{
+				"BytesToYAMLDocWith", "DocumentsToJSON", "YAMLToJSONWith",
+				"YAMLMapSlice.UnmarshalYAMLWith", "YAMLCommentedMapSlice.UnmarshalYAMLWith",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			for name, entryPoint := range entryPoints {
+				if name == "JSONToYAML" && !json.Valid(test.input) {
+					continue
+				}
+
+				t.Run(name, func(t *testing.T) {
+					err := entryPoint(test.input, WithLimits(test.limits))
+					if slices.Contains(test.enforced, name) {
+						require.ErrorIs(t, err, ErrYAML)
+
+						return
+					}
+
+					require.NoError(t, err)
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+// Option provides options to the YAML utilities, e.g. [BytesToYAMLDocWith].
+type Option func(*options)
+
+type options struct {
+	anyRoot   bool
+	positions Positions
+	limits    Limits
+
+	yaml11     bool
+	yaml11Warn func(YAML11Warning)
+
+	indent        int
+	flowMaxItems  int
+	literalBlocks bool
+	quoting       Quoting
+}
+
+func optionsWithDefaults(opts []Option) options {
+	o := options{
+		literalBlocks: true,
+	}
+
+	for _, apply := range opts {
+		apply(&o)
+	}
+
+	return o
+}
+
+// WithAllowAnyRoot allows YAML documents with a root that is not an object,
+// e.g. an array or a scalar.
+//
+// By default, only YAML documents that are objects are supported.
+func WithAllowAnyRoot(enabled bool) Option {
+	return func(o *options) {
+		o.anyRoot = enabled
+	}
+}
+
+// WithPositions collects into positions the location in the YAML source of every value
+// converted by [YAMLToJSONWith], keyed by JSON pointer (RFC 6901).
+//
+// The root value is keyed by the empty pointer "".
+// The positions map must not be nil.
+func WithPositions(positions Positions) Option {
+	return func(o *options) {
+		o.positions = positions
+	}
+}
+
+// WithIndent sets the number of spaces used by the [Encoder] to indent nested YAML nodes.
+//
+// A value lower than or equal to 0 means the default, i.e. 4 spaces.
+func WithIndent(spaces int) Option {
+	return func(o *options) {
+		o.indent = spaces
+	}
+}
+
+// WithFlowSequences tells the [Encoder] to render arrays of at most maxItems scalar values
+// in the flow style, e.g. "[a, b, c]".
+//
+// By default, all non-empty arrays are rendered in the block style, one item per line.
+func WithFlowSequences(maxItems int) Option {
+	return func(o *options) {
+		o.flowMaxItems = maxItems
+	}
+}
+
+// WithLiteralBlocks tells the [Encoder] whether to render multi-line strings as literal blocks, e.g. "|".
+//
+// This is enabled by default. When disabled, multi-line strings are rendered as double-quoted strings.
+func WithLiteralBlocks(enabled bool) Option {
+	return func(o *options) {
+		o.literalBlocks = enabled
+	}
+}
+
+// WithQuoting sets the rules used by the [Encoder] to quote strings.
+//
+// The default is [QuoteWhenRequired].
+func WithQuoting(quoting Quoting) Option {
+	return func(o *options) {
+		o.quoting = quoting
+	}
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestBytesToYAMLDocWith(t *testing.T) {
+	t.Run("with default options", func(t *testing.T) {
+		t.Run("should convert an object root", func(t *testing.T) {
+			doc, err := BytesToYAMLDocWith([]byte("name: hello\n"))
+			require.NoError(t, err)
+
+			d, err := YAMLToJSON(doc)
+			require.NoError(t, err)
+			assert.JSONEqBytes(t, []byte(`{"name":"hello"}`), d)
+		})
+
+		t.Run("should not convert an array root", func(t *testing.T) {
+			_, err := BytesToYAMLDocWith([]byte("- name: hello\n"))
+			require.ErrorIs(t, err, ErrYAML)
+		})
+
+		t.Run("should not convert a scalar root", func(t *testing.T) {
+			_, err := BytesToYAMLDocWith([]byte("hello\n"))
+			require.ErrorIs(t, err, ErrYAML)
+		})
+	})
+
+	t.Run("with any root allowed", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			input    string
+			expected string
+		}{
+			{name: "object", input: "name: hello\n", expected: `{"name":"hello"}`},
+			{name: "array", input: "- name: hello\n- 1\n", expected: `[{"name":"hello"},1]`},
+			{name: "string", input: "hello\n", expected: `"hello"`},
+			{name: "integer", input: "42\n", expected: `42`},
+			{name: "boolean", input: "true\n", expected: `true`},
+			{name: "null", input: "null\n", expected: `null`},
+			{name: "anchored array", input: "- &a x\n- *a\n", expected: `["x","x"]`},
+		} {
+			t.Run("should convert a root of type "+tc.name, func(t *testing.T) {
+				doc, err := BytesToYAMLDocWith([]byte(tc.input), WithAllowAnyRoot(true))
+				require.NoError(t, err)
+
+				d, err := YAMLToJSON(doc)
+				require.NoError(t, err)
+				assert.JSONEqBytes(t, []byte(tc.expected), d)
+			})
+		}
+
+		t.Run("should not convert an empty document", func(t *testing.T) {
+			_, err := BytesToYAMLDocWith([]byte(""), WithAllowAnyRoot(true))
+			require.ErrorIs(t, err, ErrYAML)
+		})
+
+		t.Run("should not convert invalid YAML", func(t *testing.T) {
+			_, err := BytesToYAMLDocWith([]byte("name:\tgreetings: hello\n"), WithAllowAnyRoot(true))
+			require.Error(t, err)
+		})
+
+		t.Run("should iterate over documents with any root", func(t *testing.T) {
+			var actual []string
+			for d, err := range DocumentsToJSON([]byte("- a\n---\nb\n---\nc: d\n"), WithAllowAnyRoot(true)) {
+				require.NoError(t, err)
+				actual = append(actual, string(d))
+			}
+
+			assert.Equal(t, []string{`["a"]`, `"b"`, `{"c":"d"}`}, actual)
+		})
+	})
+}
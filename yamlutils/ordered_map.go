@@ -156,12 +156,21 @@ func (s *YAMLMapSlice) UnmarshalYAML(node *yaml.Node) error {
 	return s.unmarshalYAML(newYAMLWalker(), node, 0)
 }
 
+// UnmarshalYAMLWith builds a YAMLMapSlice object from a YAML document [yaml.Node], with options.
+//
+// See [YAMLMapSlice.UnmarshalYAML]. Use [WithLimits] to bound the resources spent on the document.
+func (s *YAMLMapSlice) UnmarshalYAMLWith(node *yaml.Node, opts ...Option) error {
+	o := optionsWithDefaults(opts)
+
+	return s.unmarshalYAML(o.walker(), node, 0)
+}
+
 // unmarshalYAML builds the slice from a [yaml.Node], tracking the recursion depth (against
 // stack-overflow) and threading the [yamlWalker] so anchor/alias expansion stays bounded
 // across the whole document.
 func (s *YAMLMapSlice) unmarshalYAML(w *yamlWalker, node *yaml.Node, depth int) error {
-	if depth > defaultMaxNestingDepth {
-		return errMaxNestingDepth
+	if err := w.checkDepth(depth); err != nil {
+		return withPosition(node, err)
 	}
 
 	if typeutils.IsNil(*s) {
@@ -179,16 +188,16 @@ func (s *YAMLMapSlice) unmarshalYAML(w *yamlWalker, node *yaml.Node, depth int)
 
 	for i := 0; i < len(node.Content); i += 2 {
 		if err := w.account(); err != nil { // account the key node
-			return err
+			return withPosition(node.Content[i], err)
 		}
 
 		var nmi YAMLMapItem
 		k, err := yamlStringScalarC(node.Content[i])
 		if err != nil {
-			return fmt.Errorf("unable to decode YAML map key: %w: %w", err, ErrYAML)
+			return fmt.Errorf("unable to decode YAML map key: %w: %w", withPosition(node.Content[i], err), ErrYAML)
 		}
 		nmi.Key = k
-		v, err := w.node(node.Content[i+1], depth+1)
+		v, err := w.child(node.Content[i+1], k, depth+1)
 		if err != nil {
 			return fmt.Errorf("unable to process YAML map value for key %q: %w: %w", k, err, ErrYAML)
 		}
@@ -214,6 +223,8 @@ func json2yaml(item any, depth int) (*yaml.Node, error) {
 	}
 
 	switch val := item.(type) {
+	case YAMLCommentedMapSlice:
+		return val.mappingNode(depth)
 	case ifaces.Ordered:
 		return orderedYAML(val, depth)
 
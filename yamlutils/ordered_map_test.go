@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/go-openapi/swag/jsonutils"
 	fixtures "github.com/go-openapi/swag/jsonutils/fixtures_test"
 	"github.com/go-openapi/testify/v2/assert"
 	"github.com/go-openapi/testify/v2/require"
@@ -367,3 +368,76 @@ func TestSetOrdered(t *testing.T) {
 
 	})
 }
+
+func TestJSONPatch(t *testing.T) {
+	t.Parallel()
+
+	const doc = `
+b: 1
+a:
+  d: x
+  c: [1, 2]
+`
+	var data YAMLMapSlice
+	require.NoError(t, yaml.Unmarshal([]byte(doc), &data))
+
+	t.Run("should patch a YAML document with the order of keys maintained", func(t *testing.T) {
+		patched, err := jsonutils.ApplyPatch(data, jsonutils.Patch{
+			{Op: jsonutils.OpReplace, Path: "/a/d", Value: "z"},
+			{Op: jsonutils.OpAdd, Path: "/a/c/-", Value: 3},
+			{Op: jsonutils.OpAdd, Path: "/0", Value: true},
+		})
+		require.NoError(t, err)
+		require.IsType(t, YAMLMapSlice{}, patched)
+
+		y, err := patched.(YAMLMapSlice).MarshalYAML()
+		require.NoError(t, err)
+		assert.EqualT(t, "b: 1\na:\n    d: z\n    c:\n        - 1\n        - 2\n        - 3\n\"0\": true\n", string(y.([]byte)))
+	})
+
+	t.Run("should diff YAML documents", func(t *testing.T) {
+		var other YAMLMapSlice
+		require.NoError(t, yaml.Unmarshal([]byte("a:\n  c: [1]\n  d: x\n"), &other))
+
+		patch := jsonutils.Diff(data, other)
+		require.Equal(t, jsonutils.Patch{
+			{Op: jsonutils.OpRemove, Path: "/b"},
+			{Op: jsonutils.OpRemove, Path: "/a/c/1"},
+		}, patch)
+	})
+}
+
+func TestJSONPointer(t *testing.T) {
+	t.Parallel()
+
+	const doc = `
+b: 1
+a:
+  d: x
+  c: [1, 2]
+`
+	var data YAMLMapSlice
+	require.NoError(t, yaml.Unmarshal([]byte(doc), &data))
+
+	t.Run("should get a value from a YAML document", func(t *testing.T) {
+		value, err := jsonutils.Get(data, "/a/c/1")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), value)
+	})
+
+	t.Run("should set values in a YAML document with the order of keys maintained", func(t *testing.T) {
+		updated, err := jsonutils.Set(data, "/a/e/f", "z", jsonutils.WithIntermediates(true))
+		require.NoError(t, err)
+		updated, err = jsonutils.Delete(updated, "/a/c")
+		require.NoError(t, err)
+		require.IsType(t, YAMLMapSlice{}, updated)
+
+		created, err := jsonutils.Get(updated, "/a/e")
+		require.NoError(t, err)
+		require.IsType(t, YAMLMapSlice{}, created)
+
+		y, err := updated.(YAMLMapSlice).MarshalYAML()
+		require.NoError(t, err)
+		assert.EqualT(t, "b: 1\na:\n    d: x\n    e:\n        f: z\n", string(y.([]byte)))
+	})
+}
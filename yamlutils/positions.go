@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Position is a location in a YAML source. Lines and columns start at 1.
+type Position struct {
+	Line   int
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("line %d, column %d", p.Line, p.Column)
+}
+
+// Positions maps the JSON pointers (RFC 6901) of the values in a JSON document
+// to their [Position] in the YAML source it was converted from.
+//
+// See [WithPositions].
+type Positions map[string]Position
+
+var pointerTokenEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func escapePointerToken(token string) string {
+	return pointerTokenEscaper.Replace(token)
+}
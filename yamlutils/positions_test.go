@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+	yaml "go.yaml.in/yaml/v3"
+)
+
+func TestPositionError(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		input    string
+		expected Position
+	}{
+		{
+			name:     "invalid integer scalar",
+			input:    "a:\n  b: !!int x\n",
+			expected: Position{Line: 2, Column: 6},
+		},
+		{
+			name:     "unsupported tag",
+			input:    "a:\n  - 1\n  - !!binary aGVsbG8=\n",
+			expected: Position{Line: 3, Column: 5},
+		},
+		{
+			name:     "unsupported map key",
+			input:    "a: 1\n? [x]\n: 2\n",
+			expected: Position{Line: 2, Column: 3},
+		},
+	} {
+		t.Run("should report the position of an error on "+tc.name, func(t *testing.T) {
+			doc, err := BytesToYAMLDoc([]byte(tc.input))
+			require.NoError(t, err)
+
+			_, err = YAMLToJSON(doc)
+			require.Error(t, err)
+			require.ErrorIs(t, err, ErrYAML)
+
+			var perr *PositionError
+			require.TrueT(t, errors.As(err, &perr))
+			assert.EqualT(t, tc.expected, perr.Position)
+			assert.StringContainsT(t, err.Error(), tc.expected.String())
+		})
+	}
+
+	t.Run("should report the position of an error when unmarshaling YAMLMapSlice", func(t *testing.T) {
+		var data YAMLMapSlice
+		err := yaml.Unmarshal([]byte("a: 1\nb: !!float x\n"), &data)
+		require.Error(t, err)
+
+		var perr *PositionError
+		require.TrueT(t, errors.As(err, &perr))
+		assert.EqualT(t, Position{Line: 2, Column: 4}, perr.Position)
+	})
+
+	t.Run("should not report a position for synthetic nodes", func(t *testing.T) {
+		_, err := YAMLToJSON(&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!binary", Value: "x"})
+		require.Error(t, err)
+
+		var perr *PositionError
+		assert.FalseT(t, errors.As(err, &perr))
+	})
+}
+
+func TestYAMLToJSONWithPositions(t *testing.T) {
+	const input = `openapi: 3.1.0
+info:
+  title: positions
+paths:
+  /pets/{id}:
+    get:
+      tags: [pets, "a~b"]
+list:
+  - &item
+    name: x
+  - *item
+`
+	doc, err := BytesToYAMLDoc([]byte(input))
+	require.NoError(t, err)
+
+	positions := make(Positions)
+	d, err := YAMLToJSONWith(doc, WithPositions(positions))
+	require.NoError(t, err)
+	require.NotEmpty(t, d)
+
+	for pointer, expected := range map[string]Position{
+		"":                               {Line: 1, Column: 1},
+		"/openapi":                       {Line: 1, Column: 10},
+		"/info":                          {Line: 3, Column: 3},
+		"/info/title":                    {Line: 3, Column: 10},
+		"/paths/~1pets~1{id}/get":        {Line: 7, Column: 7},
+		"/paths/~1pets~1{id}/get/tags/1": {Line: 7, Column: 20},
+		"/list/0":                        {Line: 9, Column: 5},
+		"/list/0/name":                   {Line: 10, Column: 11},
+		"/list/1":                        {Line: 11, Column: 5},
+		"/list/1/name":                   {Line: 10, Column: 11},
+	} {
+		pos, ok := positions[pointer]
+		require.TrueT(t, ok, "missing position for %q", pointer)
+		assert.EqualT(t, expected, pos, "unexpected position for %q", pointer)
+	}
+
+	t.Run("should not record positions by default", func(t *testing.T) {
+		w := newYAMLWalker()
+		_, err := w.transform(doc, 0)
+		require.NoError(t, err)
+		assert.Empty(t, w.pointer)
+		assert.Nil(t, w.positions)
+	})
+}
@@ -46,11 +46,14 @@ const (
 
 var (
 	// errMaxNestingDepth is returned when a document nests deeper than [defaultMaxNestingDepth].
-	errMaxNestingDepth = fmt.Errorf("maximum nesting depth of %d exceeded: %w", defaultMaxNestingDepth, ErrYAML)
+	errMaxNestingDepth = maxNestingDepthError(defaultMaxNestingDepth)
 
 	// errExcessiveAliasing is returned when anchor/alias expansion is disproportionate to the
 	// size of the document, i.e. an "alias bomb".
 	errExcessiveAliasing = fmt.Errorf("document contains excessive aliasing: %w", ErrYAML)
+
+	// errAliasesDisabled is returned when a document contains anchors or aliases, and [Limits.DisableAliases] is set.
+	errAliasesDisabled = fmt.Errorf("YAML anchors and aliases are not allowed: %w", ErrYAML)
 )
 
 // allowedAliasRatio scales the tolerated share of alias-driven decode operations from 99%
@@ -71,15 +74,53 @@ func allowedAliasRatio(decodeCount int) float64 {
 //
 // A fresh walker is created per top-level conversion; it is threaded (not copied) through
 // the whole recursive walk so its counters accumulate across the entire document.
+//
+// When positions is not nil, the walker also records the source position of every value
+// it visits, keyed by the JSON pointer of the value being built.
 type yamlWalker struct {
 	decodeCount int
 	aliasCount  int
 	aliasDepth  int
 	aliases     map[*yaml.Node]bool // anchors currently being expanded, for cycle detection
+	positions   Positions
+	pointer     string // JSON pointer to the current value, only maintained when positions are recorded
+	comments    bool   // when enabled, objects are built as [YAMLCommentedMapSlice]
+	limits      Limits
+	maxDepth    int
+	yaml11      bool
+	yaml11Warn  func(YAML11Warning)
 }
 
 func newYAMLWalker() *yamlWalker {
-	return &yamlWalker{aliases: make(map[*yaml.Node]bool)}
+	return &yamlWalker{
+		aliases:  make(map[*yaml.Node]bool),
+		maxDepth: defaultMaxNestingDepth,
+	}
+}
+
+// walker builds a [yamlWalker] configured with these options.
+func (o options) walker() *yamlWalker {
+	w := newYAMLWalker()
+	w.positions = o.positions
+	w.limits = o.limits
+	w.maxDepth = o.limits.maxDepth()
+	w.yaml11 = o.yaml11
+	w.yaml11Warn = o.yaml11Warn
+
+	return w
+}
+
+// checkDepth verifies that the nesting depth is within the configured limit.
+func (w *yamlWalker) checkDepth(depth int) error {
+	if depth > w.maxDepth {
+		if w.maxDepth == defaultMaxNestingDepth {
+			return errMaxNestingDepth
+		}
+
+		return maxNestingDepthError(w.maxDepth)
+	}
+
+	return nil
 }
 
 // account records one processed node and fails if alias expansion has become excessive.
@@ -95,6 +136,14 @@ func (w *yamlWalker) account() error {
 		return errExcessiveAliasing
 	}
 
+	if w.limits.MaxNodes > 0 && w.decodeCount > w.limits.MaxNodes {
+		return maxNodesError(w.limits.MaxNodes)
+	}
+
+	if w.limits.MaxAliasExpansions > 0 && w.aliasCount > w.limits.MaxAliasExpansions {
+		return maxAliasExpansionsError(w.limits.MaxAliasExpansions)
+	}
+
 	return nil
 }
 
@@ -103,8 +152,23 @@ func (w *yamlWalker) account() error {
 // Note: a YAML document is the output from a [yaml.Marshaler], e.g a pointer to a [yaml.Node].
 //
 // [YAMLToJSON] is typically called after [BytesToYAMLDoc].
+//
+// Errors raised while converting a [yaml.Node] report the position in the YAML source
+// where the problem occurred, as a [PositionError].
 func YAMLToJSON(value any) (json.RawMessage, error) {
-	jm, err := transformData(value, 0)
+	return YAMLToJSONWith(value)
+}
+
+// YAMLToJSONWith converts a YAML document into JSON bytes, with options.
+//
+// See [YAMLToJSON].
+//
+// Use [WithPositions] to collect the position in the YAML source of every value of the
+// resulting JSON document, and [WithLimits] to bound the resources spent on the conversion.
+func YAMLToJSONWith(value any, opts ...Option) (json.RawMessage, error) {
+	o := optionsWithDefaults(opts)
+
+	jm, err := o.walker().transform(value, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -130,13 +194,98 @@ func BytesToYAMLDoc(data []byte) (any, error) {
 	return &document, nil
 }
 
+// BytesToYAMLDocWith converts a byte slice into a YAML document, with options.
+//
+// Unlike [BytesToYAMLDoc], it supports documents with a root that is not an object
+// when the [WithAllowAnyRoot] option is enabled. Such documents are converted by [YAMLToJSON]
+// into the matching JSON, e.g. an array or a scalar.
+//
+// The [WithLimits] option bounds the resources spent on the document. The same limits should be
+// passed to [YAMLToJSONWith], which enforces the limits that depend on the expansion of aliases.
+//
+// A YAML document is a pointer to a [yaml.Node].
+func BytesToYAMLDocWith(data []byte, opts ...Option) (any, error) {
+	o := optionsWithDefaults(opts)
+
+	if err := o.limits.checkSize(data); err != nil {
+		return nil, err
+	}
+
+	var document yaml.Node // preserve order that is present in the document
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	if err := o.checkRoot(&document); err != nil {
+		return nil, err
+	}
+
+	if err := o.limits.check(&document, new(int)); err != nil {
+		return nil, err
+	}
+
+	return &document, nil
+}
+
+// checkRoot verifies that the root of a YAML document is supported.
+func (o options) checkRoot(document *yaml.Node) error {
+	if document.Kind != yaml.DocumentNode || len(document.Content) != 1 {
+		return fmt.Errorf("empty or invalid YAML document: %w", ErrYAML)
+	}
+
+	if !o.anyRoot && document.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("only YAML documents that are objects are supported: %w", ErrYAML)
+	}
+
+	return nil
+}
+
+// node converts a YAML node into a JSON-compatible value.
+//
+// Errors are reported with the position of the innermost node that failed.
 func (w *yamlWalker) node(root *yaml.Node, depth int) (any, error) {
-	if depth > defaultMaxNestingDepth {
-		return nil, errMaxNestingDepth
+	out, err := w.walk(root, depth)
+	if err != nil {
+		return nil, withPosition(root, err)
+	}
+
+	return out, nil
+}
+
+// child converts a YAML node that is an element of the current value, addressed by token.
+func (w *yamlWalker) child(node *yaml.Node, token string, depth int) (any, error) {
+	if w.positions == nil && w.yaml11Warn == nil {
+		return w.node(node, depth)
+	}
+
+	parent := w.pointer
+	w.pointer = parent + "/" + escapePointerToken(token)
+	out, err := w.node(node, depth)
+	w.pointer = parent
+
+	return out, err
+}
+
+// record saves the position of the current value, when positions are collected.
+func (w *yamlWalker) record(node *yaml.Node) {
+	if w.positions == nil || node.Kind == yaml.DocumentNode {
+		return
+	}
+
+	w.positions[w.pointer] = Position{Line: node.Line, Column: node.Column}
+}
+
+func (w *yamlWalker) walk(root *yaml.Node, depth int) (any, error) {
+	if err := w.checkDepth(depth); err != nil {
+		return nil, err
 	}
 	if err := w.account(); err != nil {
 		return nil, err
 	}
+	if w.limits.DisableAliases && (root.Anchor != "" || root.Kind == yaml.AliasNode) {
+		return nil, errAliasesDisabled
+	}
+	w.record(root)
 
 	switch root.Kind {
 	case yaml.DocumentNode:
@@ -146,7 +295,7 @@ func (w *yamlWalker) node(root *yaml.Node, depth int) (any, error) {
 	case yaml.MappingNode:
 		return w.mapping(root, depth)
 	case yaml.ScalarNode:
-		return yamlScalar(root)
+		return w.scalar(root)
 	case yaml.AliasNode:
 		return w.alias(root, depth)
 	default:
@@ -170,6 +319,7 @@ func (w *yamlWalker) alias(node *yaml.Node, depth int) (any, error) {
 	out, err := w.node(node.Alias, depth+1)
 	w.aliasDepth--
 	delete(w.aliases, node.Alias)
+	w.record(node) // the value is located where the alias is used, not where the anchor is defined
 
 	return out, err
 }
@@ -183,6 +333,15 @@ func (w *yamlWalker) document(node *yaml.Node, depth int) (any, error) {
 
 func (w *yamlWalker) mapping(node *yaml.Node, depth int) (any, error) {
 	const sensibleAllocDivider = 2 // nodes concatenate (key,value) sequences
+	if w.comments {
+		m := make(YAMLCommentedMapSlice, 0, len(node.Content)/sensibleAllocDivider)
+		if err := m.unmarshalYAML(w, node, depth); err != nil {
+			return nil, err
+		}
+
+		return m, nil
+	}
+
 	m := make(YAMLMapSlice, len(node.Content)/sensibleAllocDivider)
 
 	if err := m.unmarshalYAML(w, node, depth); err != nil {
@@ -196,7 +355,7 @@ func (w *yamlWalker) sequence(node *yaml.Node, depth int) (any, error) {
 	s := make([]any, 0)
 
 	for i := range len(node.Content) {
-		v, err := w.node(node.Content[i], depth+1)
+		v, err := w.child(node.Content[i], strconv.Itoa(i), depth+1)
 		if err != nil {
 			return nil, fmt.Errorf("unable to decode YAML sequence value: %w: %w", err, ErrYAML)
 		}
@@ -288,15 +447,19 @@ func format(t any) (string, error) {
 }
 
 func transformData(input any, depth int) (out any, err error) {
-	if depth > defaultMaxNestingDepth {
-		return nil, errMaxNestingDepth
+	return newYAMLWalker().transform(input, depth)
+}
+
+func (w *yamlWalker) transform(input any, depth int) (out any, err error) {
+	if err := w.checkDepth(depth); err != nil {
+		return nil, err
 	}
 
 	switch in := input.(type) {
 	case yaml.Node:
-		return newYAMLWalker().node(&in, depth)
+		return w.node(&in, depth)
 	case *yaml.Node:
-		return newYAMLWalker().node(in, depth)
+		return w.node(in, depth)
 	case map[any]any:
 		o := make(YAMLMapSlice, 0, len(in))
 		for ke, va := range in {
@@ -305,7 +468,7 @@ func transformData(input any, depth int) (out any, err error) {
 				return nil, err
 			}
 
-			v, ert := transformData(va, depth+1)
+			v, ert := w.transform(va, depth+1)
 			if ert != nil {
 				return nil, ert
 			}
@@ -317,7 +480,7 @@ func transformData(input any, depth int) (out any, err error) {
 		len1 := len(in)
 		o := make([]any, len1)
 		for i := range len1 {
-			o[i], err = transformData(in[i], depth+1)
+			o[i], err = w.transform(in[i], depth+1)
 			if err != nil {
 				return nil, err
 			}
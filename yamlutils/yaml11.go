@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	yaml "go.yaml.in/yaml/v3"
+)
+
+// YAML11Warning reports a scalar value that has a different meaning in YAML 1.1 and in YAML 1.2.
+//
+// See [WithYAML11Warnings].
+type YAML11Warning struct {
+	Position
+
+	// Pointer is the JSON pointer (RFC 6901) to the value in the converted JSON document.
+	Pointer string
+	// Value is the scalar as found in the YAML source.
+	Value string
+	// YAML11 is the value of the scalar in YAML 1.1.
+	YAML11 any
+	// YAML12 is the value of the scalar in YAML 1.2, or nil if the scalar is not supported in YAML 1.2.
+	YAML12 any
+}
+
+func (w YAML11Warning) String() string {
+	return fmt.Sprintf("%v: %q at %q is %#v in YAML 1.1 and %#v in YAML 1.2", w.Position, w.Value, w.Pointer, w.YAML11, w.YAML12)
+}
+
+// WithYAML11 tells the conversion of YAML documents, e.g. [YAMLToJSONWith], to resolve plain scalars
+// with the YAML 1.1 rules, for documents written for YAML 1.1 parsers such as older Swagger 2.0 specs.
+//
+// With YAML 1.1, "yes", "no", "on", "off" (and their variants) are booleans, numbers may be written in
+// sexagesimal (e.g. "1:20") or octal with a leading "0", and so on.
+//
+// Quoted scalars, scalars with an explicit tag and keys are not affected.
+//
+// By default, plain scalars are resolved with the YAML 1.2 rules.
+func WithYAML11(enabled bool) Option {
+	return func(o *options) {
+		o.yaml11 = enabled
+	}
+}
+
+// WithYAML11Warnings calls warn for every plain scalar that has a different meaning in YAML 1.1 and in YAML 1.2,
+// when converting YAML documents, e.g. with [YAMLToJSONWith].
+//
+// Warnings are reported whether or not [WithYAML11] is enabled.
+func WithYAML11Warnings(warn func(YAML11Warning)) Option {
+	return func(o *options) {
+		o.yaml11Warn = warn
+	}
+}
+
+// scalar converts a scalar node, resolving it with the YAML 1.1 rules if enabled.
+func (w *yamlWalker) scalar(node *yaml.Node) (any, error) {
+	if (!w.yaml11 && w.yaml11Warn == nil) || !isPlainScalar(node) {
+		return yamlScalar(node)
+	}
+
+	v12, err := yamlScalar(node)
+	v11, ok := resolveYAML11(node.Value)
+	if !ok || (err == nil && v11 == v12) {
+		return v12, err
+	}
+
+	if err != nil {
+		v12 = nil
+	}
+
+	if w.yaml11Warn != nil {
+		w.yaml11Warn(YAML11Warning{
+			Position: Position{Line: node.Line, Column: node.Column},
+			Pointer:  w.pointer,
+			Value:    node.Value,
+			YAML11:   v11,
+			YAML12:   v12,
+		})
+	}
+
+	if w.yaml11 {
+		return v11, nil
+	}
+
+	return v12, err
+}
+
+// isPlainScalar reports whether a scalar node is unquoted and its tag is implicit, i.e. resolved from its value.
+func isPlainScalar(node *yaml.Node) bool {
+	const notPlain = yaml.TaggedStyle | yaml.DoubleQuotedStyle | yaml.SingleQuotedStyle | yaml.LiteralStyle | yaml.FoldedStyle
+
+	return node.Kind == yaml.ScalarNode && node.Style&notPlain == 0
+}
+
+var (
+	yaml11Booleans = map[string]bool{
+		"y": true, "Y": true, "yes": true, "Yes": true, "YES": true,
+		"true": true, "True": true, "TRUE": true,
+		"on": true, "On": true, "ON": true,
+		"n": false, "N": false, "no": false, "No": false, "NO": false,
+		"false": false, "False": false, "FALSE": false,
+		"off": false, "Off": false, "OFF": false,
+	}
+
+	yaml11Nulls = map[string]struct{}{
+		"": {}, "~": {}, "null": {}, "Null": {}, "NULL": {},
+	}
+
+	// See https://yaml.org/type/int.html and https://yaml.org/type/float.html
+	yaml11Binary      = regexp.MustCompile(`^[-+]?0b[01_]+$`)
+	yaml11Octal       = regexp.MustCompile(`^[-+]?0[0-7_]+$`)
+	yaml11Decimal     = regexp.MustCompile(`^[-+]?(0|[1-9][0-9_]*)$`)
+	yaml11Hexadecimal = regexp.MustCompile(`^[-+]?0x[0-9a-fA-F_]+$`)
+	yaml11Sexagesimal = regexp.MustCompile(`^[-+]?[0-9][0-9_]*(:[0-5]?[0-9])+(\.[0-9_]*)?$`)
+	yaml11Float       = regexp.MustCompile(`^[-+]?([0-9][0-9_]*)?\.[0-9_]*([eE][-+][0-9]+)?$`)
+	yaml11Special     = regexp.MustCompile(`^([-+]?\.(inf|Inf|INF)|\.(nan|NaN|NAN))$`)
+)
+
+// resolveYAML11 resolves a plain scalar with the YAML 1.1 rules.
+//
+// It returns false when the scalar has no JSON equivalent (e.g. infinity) or can't be represented
+// (e.g. an integer that overflows), so the YAML 1.2 resolution applies.
+func resolveYAML11(value string) (any, bool) {
+	if b, ok := yaml11Booleans[value]; ok {
+		return b, true
+	}
+
+	if _, ok := yaml11Nulls[value]; ok {
+		return nil, true
+	}
+
+	sign, digits := splitSign(strings.ReplaceAll(value, "_", ""))
+
+	switch {
+	case yaml11Special.MatchString(value):
+		return nil, false
+	case yaml11Binary.MatchString(value):
+		return parseYAML11Int(sign, digits[2:], 2) //nolint:mnd // binary
+	case yaml11Hexadecimal.MatchString(value):
+		return parseYAML11Int(sign, digits[2:], 16) //nolint:mnd // hexadecimal
+	case yaml11Octal.MatchString(value):
+		return parseYAML11Int(sign, digits[1:], 8) //nolint:mnd // octal
+	case yaml11Decimal.MatchString(value):
+		return parseYAML11Int(sign, digits, 10) //nolint:mnd // decimal
+	case yaml11Sexagesimal.MatchString(value):
+		return parseYAML11Sexagesimal(sign, digits)
+	case yaml11Float.MatchString(value) && strings.ContainsAny(value, "0123456789"):
+		f, err := strconv.ParseFloat(sign+digits, 64)
+		if err != nil {
+			return nil, false
+		}
+
+		return f, true
+	default:
+		return value, true
+	}
+}
+
+func splitSign(value string) (string, string) {
+	if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		return value[:1], value[1:]
+	}
+
+	return "", value
+}
+
+func parseYAML11Int(sign, digits string, base int) (any, bool) {
+	i, err := strconv.ParseInt(sign+digits, base, 64)
+	if err != nil {
+		return nil, false
+	}
+
+	return i, true
+}
+
+// parseYAML11Sexagesimal parses a base 60 number, e.g. "1:20" (80) or "1:20.5" (80.5).
+func parseYAML11Sexagesimal(sign, digits string) (any, bool) {
+	const base = 60
+
+	parts := strings.Split(digits, ":")
+	last := parts[len(parts)-1]
+	isFloat := strings.Contains(last, ".")
+
+	var total float64
+	for _, part := range parts {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return nil, false
+		}
+
+		total = total*base + v
+	}
+
+	if sign == "-" {
+		total = -total
+	}
+
+	if isFloat {
+		return total, true
+	}
+
+	const maxExactInteger = 1 << 53
+	if total > maxExactInteger || total < -maxExactInteger {
+		return nil, false
+	}
+
+	return int64(total), true
+}
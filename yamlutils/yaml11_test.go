@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package yamlutils
+
+import (
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+const yaml11Fixture = `swagger: "2.0"
+info:
+  x-enabled: yes
+  x-quoted: "on"
+  x-tagged: !!str off
+  x-duration: 1:20
+  x-mode: 0644
+  x-exponent: 1e3
+  x-name: sample
+off: n
+`
+
+func TestYAML11(t *testing.T) {
+	t.Parallel()
+
+	doc, err := BytesToYAMLDoc([]byte(yaml11Fixture))
+	require.NoError(t, err)
+
+	t.Run("should resolve scalars with YAML 1.2 by default", func(t *testing.T) {
+		d, err := YAMLToJSONWith(doc)
+		require.NoError(t, err)
+		assert.JSONEqBytes(t, []byte(`{
+			"swagger":"2.0",
+			"info":{"x-enabled":"yes","x-quoted":"on","x-tagged":"off","x-duration":"1:20","x-mode":644,"x-exponent":1000,"x-name":"sample"},
+			"off":"n"
+		}`), d)
+	})
+
+	t.Run("should resolve plain scalars with YAML 1.1", func(t *testing.T) {
+		d, err := YAMLToJSONWith(doc, WithYAML11(true))
+		require.NoError(t, err)
+		assert.JSONEqBytes(t, []byte(`{
+			"swagger":"2.0",
+			"info":{"x-enabled":true,"x-quoted":"on","x-tagged":"off","x-duration":80,"x-mode":420,"x-exponent":"1e3","x-name":"sample"},
+			"off":false
+		}`), d)
+	})
+
+	t.Run("should warn about scalars with a different meaning", func(t *testing.T) {
+		var warnings []YAML11Warning
+		d, err := YAMLToJSONWith(doc, WithYAML11Warnings(func(w YAML11Warning) {
+			warnings = append(warnings, w)
+		}))
+		require.NoError(t, err)
+		assert.StringContainsT(t, string(d), `"x-enabled":"yes"`, "warnings alone should not change the conversion")
+
+		assert.Equal(t, []YAML11Warning{
+			{Position: Position{Line: 3, Column: 14}, Pointer: "/info/x-enabled", Value: "yes", YAML11: true, YAML12: "yes"},
+			{Position: Position{Line: 6, Column: 15}, Pointer: "/info/x-duration", Value: "1:20", YAML11: int64(80), YAML12: "1:20"},
+			{Position: Position{Line: 7, Column: 11}, Pointer: "/info/x-mode", Value: "0644", YAML11: int64(420), YAML12: int64(644)},
+			{Position: Position{Line: 8, Column: 15}, Pointer: "/info/x-exponent", Value: "1e3", YAML11: "1e3", YAML12: float64(1000)},
+			{Position: Position{Line: 10, Column: 6}, Pointer: "/off", Value: "n", YAML11: false, YAML12: "n"},
+		}, warnings)
+		assert.StringContainsT(t, warnings[0].String(), `line 3, column 14: "yes" at "/info/x-enabled" is true in YAML 1.1`)
+	})
+
+	t.Run("should resolve scalars not supported by YAML 1.2", func(t *testing.T) {
+		doc, err := BytesToYAMLDoc([]byte("a: 1_000\nb: 0b101\n"))
+		require.NoError(t, err)
+
+		_, err = YAMLToJSONWith(doc)
+		require.Error(t, err)
+
+		var warnings []YAML11Warning
+		d, err := YAMLToJSONWith(doc, WithYAML11(true), WithYAML11Warnings(func(w YAML11Warning) {
+			warnings = append(warnings, w)
+		}))
+		require.NoError(t, err)
+		assert.JSONEqBytes(t, []byte(`{"a":1000,"b":5}`), d)
+		require.Len(t, warnings, 2)
+		assert.Nil(t, warnings[0].YAML12)
+	})
+}
+
+func TestResolveYAML11(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		value    string
+		expected any
+	}{
+		{"Yes", true},
+		{"OFF", false},
+		{"~", nil},
+		{"0b1010", int64(10)},
+		{"-0b1010", int64(-10)},
+		{"0x_1F", int64(31)},
+		{"017", int64(15)},
+		{"-017", int64(-15)},
+		{"0", int64(0)},
+		{"1_000", int64(1000)},
+		{"190:20:30", int64(685230)},
+		{"-1:20", int64(-80)},
+		{"190:20:30.15", 685230.15},
+		{"6.8523015e+5", 685230.15},
+		{"1.5", 1.5},
+		{".5", 0.5},
+		{"1e3", "1e3"},
+		{"1.0.0", "1.0.0"},
+		{"12:60", "12:60"},
+		{".", "."},
+		{"sample", "sample"},
+	} {
+		v, ok := resolveYAML11(tc.value)
+		require.TrueT(t, ok, "expected %q to be resolved", tc.value)
+		assert.Equal(t, tc.expected, v, "unexpected value for %q", tc.value)
+	}
+
+	for _, value := range []string{".inf", "-.Inf", ".NaN", "0x7FFFFFFFFFFFFFFFF"} {
+		_, ok := resolveYAML11(value)
+		assert.FalseT(t, ok, "expected %q not to be resolved", value)
+	}
+}